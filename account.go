@@ -99,7 +99,7 @@ type PositionItem struct {
 	UnrealizedPnL        string `json:"unrealized_pnl"`
 	UnrealizedPnLPercent string `json:"unrealized_pnl_percent"`
 	IsSettle             bool   `json:"is_settle"`     // 用户是否已结算
-	MarketStatus         string `json:"market_status"` // 市场状态: open, closed, settled
+	MarketStatus         string `json:"market_status"` // 市场状态: pending, open, paused, closed, disputed, settled
 	MarketResult         string `json:"market_result"` // 市场结果: yes, no, 或空
 }
 
@@ -0,0 +1,98 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ItemIdempotencyKey derives the idempotency key of the index-th item of a
+// batch from the batch key. It returns "" when batchKey is empty.
+func ItemIdempotencyKey(batchKey string, index int) string {
+	if batchKey == "" {
+		return ""
+	}
+	return batchKey + ":" + strconv.Itoa(index)
+}
+
+// BatchItemField returns the field path of an item field in batch validation
+// errors, e.g. "list[2].user_wallet".
+func BatchItemField(index int, field string) string {
+	return fmt.Sprintf("list[%d].%s", index, field)
+}
+
+// BatchFields holds the batch-level fields inherited by the items of a batch.
+type BatchFields struct {
+	UserWallet     string
+	MarketSide     string
+	OrderDirection string
+	IdempotencyKey string
+}
+
+// BatchItemFields points at the inheritable fields of one batch item.
+type BatchItemFields struct {
+	UserWallet     *string
+	MarketSide     *string
+	OrderDirection *string
+	IdempotencyKey *string
+}
+
+// ExpandBatch returns a copy of list with the batch fields applied, using
+// fields to reach the inheritable fields of each item. It implements the
+// rules of BatchOrderCreateRequest.Expand for any order request type.
+func ExpandBatch[T any](b BatchFields, list []T, fields func(*T) BatchItemFields) ([]T, error) {
+	var errs ValidationErrors
+	if len(list) == 0 {
+		errs.Add("list", "must contain at least one order")
+	}
+
+	items := make([]T, len(list))
+	keys := make(map[string]int, len(list))
+	for i := range list {
+		items[i] = list[i]
+		f := fields(&items[i])
+		switch {
+		case *f.UserWallet == "":
+			*f.UserWallet = b.UserWallet
+		case b.UserWallet != "" && *f.UserWallet != b.UserWallet:
+			errs.Add(BatchItemField(i, "user_wallet"), "conflicts with batch user_wallet")
+		}
+		if *f.MarketSide == "" {
+			*f.MarketSide = b.MarketSide
+		}
+		if *f.OrderDirection == "" {
+			*f.OrderDirection = b.OrderDirection
+		}
+		if *f.IdempotencyKey == "" {
+			*f.IdempotencyKey = ItemIdempotencyKey(b.IdempotencyKey, i)
+		}
+		if key := *f.IdempotencyKey; key != "" {
+			if j, ok := keys[key]; ok {
+				errs.Add(BatchItemField(i, "idempotency_key"), fmt.Sprintf("duplicates list[%d]", j))
+			} else {
+				keys[key] = i
+			}
+		}
+	}
+	return items, errs.Err()
+}
+
+// Expand returns the list items with the top-level fields applied.
+// Items inherit UserWallet, MarketSide and OrderDirection when unset; items
+// without an IdempotencyKey get ItemIdempotencyKey(IdempotencyKey, index).
+// An item whose UserWallet differs from the batch wallet, or two items sharing
+// an idempotency key, are reported as ValidationErrors alongside the expansion.
+func (b *BatchOrderCreateRequest) Expand() ([]OrderCreateRequest, error) {
+	return ExpandBatch(BatchFields{
+		UserWallet:     b.UserWallet,
+		MarketSide:     b.MarketSide,
+		OrderDirection: b.OrderDirection,
+		IdempotencyKey: b.IdempotencyKey,
+	}, b.List, func(o *OrderCreateRequest) BatchItemFields {
+		return BatchItemFields{
+			UserWallet:     &o.UserWallet,
+			MarketSide:     &o.MarketSide,
+			OrderDirection: &o.OrderDirection,
+			IdempotencyKey: &o.IdempotencyKey,
+		}
+	})
+}
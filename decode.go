@@ -0,0 +1,200 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrMissingCode is returned by DecodeResponse and StreamResponse for an
+// envelope without a code field.
+var ErrMissingCode = errors.New("response has no code")
+
+// Ok returns a success Response carrying data.
+func Ok[T any](data T) Response[T] {
+	return Response[T]{Code: CodeSuccess, Message: CodeMessage(CodeSuccess), Data: data}
+}
+
+// Fail returns a failure Response with no data. An empty msg uses CodeMessage(code).
+func Fail(code int, msg string) Response[any] {
+	if msg == "" {
+		msg = CodeMessage(code)
+	}
+	return Response[any]{Code: code, Message: msg}
+}
+
+// FailErr returns a failure Response for err. An *APIError in err's chain
+// supplies the code and message; any other error maps to CodeFailed with
+// err.Error() as the message.
+func FailErr(err error) Response[any] {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return Fail(apiErr.Code, apiErr.Message)
+	}
+	return Fail(CodeFailed, err.Error())
+}
+
+// DecodeResponse reads a Response[T] envelope from r and returns its Data.
+// A non-success Code is returned as an *APIError; an envelope without a code
+// field returns an error wrapping ErrMissingCode.
+func DecodeResponse[T any](r io.Reader) (T, error) {
+	var zero T
+	var resp struct {
+		Code    *int   `json:"code"`
+		Message string `json:"message"`
+		Data    T      `json:"data"`
+	}
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		return zero, fmt.Errorf("decode response: %w", err)
+	}
+	if resp.Code == nil {
+		return zero, fmt.Errorf("decode response: %w", ErrMissingCode)
+	}
+	if *resp.Code != CodeSuccess {
+		return zero, &APIError{Code: *resp.Code, Message: resp.Message}
+	}
+	return resp.Data, nil
+}
+
+// StreamResponse reads a Response envelope from r without buffering the item
+// list, calling fn for each element as it is decoded. A non-nil error from fn
+// stops decoding and is returned.
+//
+// listField names the array inside data: "data" for PageData[T], "orders" for
+// OrderListResponse, "list" for TransactionResponse, and so on. An empty
+// listField means data itself is the array. The other fields of data (total,
+// page, ...) are returned raw.
+//
+// A non-success Code is returned as an *APIError. Servers send code before
+// data, so no items are delivered for a failed response; if code comes last,
+// items already passed to fn are not retracted. An envelope without a code
+// field returns an error wrapping ErrMissingCode.
+func StreamResponse[T any](r io.Reader, listField string, fn func(T) error) (map[string]json.RawMessage, error) {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var code int
+	var sawCode bool
+	var message string
+	var fields map[string]json.RawMessage
+	for dec.More() {
+		key, err := objectKey(dec)
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case "code":
+			sawCode = true
+			err = dec.Decode(&code)
+		case "message":
+			err = dec.Decode(&message)
+		case "data":
+			if code != CodeSuccess {
+				err = dec.Decode(new(json.RawMessage))
+				break
+			}
+			fields, err = streamData(dec, listField, fn)
+		default:
+			err = dec.Decode(new(json.RawMessage))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	if !sawCode {
+		return nil, fmt.Errorf("decode response: %w", ErrMissingCode)
+	}
+	if code != CodeSuccess {
+		return nil, &APIError{Code: code, Message: message}
+	}
+	return fields, nil
+}
+
+// streamData decodes the data value, streaming the list found at listField.
+func streamData[T any](dec *json.Decoder, listField string, fn func(T) error) (map[string]json.RawMessage, error) {
+	if listField == "" {
+		return nil, streamArray(dec, fn)
+	}
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	if tok == nil {
+		return nil, nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, fmt.Errorf("decode response: data: expected object, got %v", tok)
+	}
+	fields := make(map[string]json.RawMessage)
+	for dec.More() {
+		key, err := objectKey(dec)
+		if err != nil {
+			return nil, err
+		}
+		if key == listField {
+			if err := streamArray(dec, fn); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("decode response: data.%s: %w", key, err)
+		}
+		fields[key] = raw
+	}
+	return fields, expectDelim(dec, '}')
+}
+
+// streamArray decodes a JSON array (or null) element by element.
+func streamArray[T any](dec *json.Decoder, fn func(T) error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return fmt.Errorf("decode response: expected array, got %v", tok)
+	}
+	for i := 0; dec.More(); i++ {
+		var item T
+		if err := dec.Decode(&item); err != nil {
+			return fmt.Errorf("decode response: item %d: %w", i, err)
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, ']')
+}
+
+func objectKey(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", fmt.Errorf("decode response: %w", err)
+	}
+	key, ok := tok.(string)
+	if !ok {
+		return "", fmt.Errorf("decode response: expected object key, got %v", tok)
+	}
+	return key, nil
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("decode response: expected %q, got %v", want, tok)
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package common
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// APIError is a non-success Response code with its message.
+// Errors compare by Code, so errors.Is(err, ErrMarketClosed) matches any
+// *APIError with CodeMarketClosed regardless of Message.
+type APIError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// NewAPIError returns an *APIError for code. An empty msg uses CodeMessage(code).
+func NewAPIError(code int, msg string) *APIError {
+	if msg == "" {
+		msg = CodeMessage(code)
+	}
+	return &APIError{Code: code, Message: msg}
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api error %d: %s", e.Code, e.Message)
+}
+
+// Is reports whether target is an *APIError with the same Code.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	return ok && t.Code == e.Code
+}
+
+// HTTPStatus returns the HTTP status for e.Code. See HTTPStatus.
+func (e *APIError) HTTPStatus() int {
+	return HTTPStatus(e.Code)
+}
+
+// Sentinel errors for use with errors.Is.
+var (
+	ErrFailed                  = NewAPIError(CodeFailed, "")
+	ErrUnauthorized            = NewAPIError(CodeUnauthorized, "")
+	ErrInvalidParams           = NewAPIError(CodeInvalidParams, "")
+	ErrForbidden               = NewAPIError(CodeForbidden, "")
+	ErrNotFound                = NewAPIError(CodeNotFound, "")
+	ErrRateLimited             = NewAPIError(CodeRateLimited, "")
+	ErrInternal                = NewAPIError(CodeInternalError, "")
+	ErrMarketNotFound          = NewAPIError(CodeMarketNotFound, "")
+	ErrMarketClosed            = NewAPIError(CodeMarketClosed, "")
+	ErrInvalidPrice            = NewAPIError(CodeInvalidPrice, "")
+	ErrInvalidAmount           = NewAPIError(CodeInvalidAmount, "")
+	ErrDuplicateIdempotencyKey = NewAPIError(CodeDuplicateIdempotencyKey, "")
+	ErrOrderNotFound           = NewAPIError(CodeOrderNotFound, "")
+	ErrOrderNotCancellable     = NewAPIError(CodeOrderNotCancellable, "")
+	ErrInvalidStatusTransition = NewAPIError(CodeInvalidStatusTransition, "")
+	ErrInsufficientBalance     = NewAPIError(CodeInsufficientBalance, "")
+	ErrAccountNotFound         = NewAPIError(CodeAccountNotFound, "")
+	ErrWithdrawLimit           = NewAPIError(CodeWithdrawLimit, "")
+	ErrVenueUnavailable        = NewAPIError(CodeVenueUnavailable, "")
+	ErrVenueRejected           = NewAPIError(CodeVenueRejected, "")
+	ErrVenueTimeout            = NewAPIError(CodeVenueTimeout, "")
+)
+
+var codeMessages = map[int]string{
+	CodeSuccess:                 "success",
+	CodeFailed:                  "failed",
+	CodeUnauthorized:            "unauthorized",
+	CodeInvalidParams:           "invalid parameters",
+	CodeForbidden:               "forbidden",
+	CodeNotFound:                "not found",
+	CodeRateLimited:             "rate limited",
+	CodeInternalError:           "internal error",
+	CodeMarketNotFound:          "market not found",
+	CodeMarketClosed:            "market closed",
+	CodeInvalidPrice:            "invalid price",
+	CodeInvalidAmount:           "invalid amount",
+	CodeDuplicateIdempotencyKey: "duplicate idempotency key",
+	CodeOrderNotFound:           "order not found",
+	CodeOrderNotCancellable:     "order not cancellable",
+	CodeInvalidStatusTransition: "invalid order status transition",
+	CodeInsufficientBalance:     "insufficient balance",
+	CodeAccountNotFound:         "account not found",
+	CodeWithdrawLimit:           "withdraw limit exceeded",
+	CodeVenueUnavailable:        "venue unavailable",
+	CodeVenueRejected:           "rejected by venue",
+	CodeVenueTimeout:            "venue timeout",
+}
+
+// CodeMessage returns the default message for code, or "unknown error".
+func CodeMessage(code int) string {
+	if msg, ok := codeMessages[code]; ok {
+		return msg
+	}
+	return "unknown error"
+}
+
+// HTTPStatus maps a response code to an HTTP status. Unknown codes map to 500.
+func HTTPStatus(code int) int {
+	switch code {
+	case CodeSuccess:
+		return http.StatusOK
+	case CodeInvalidParams, CodeInvalidPrice, CodeInvalidAmount, CodeFailed:
+		return http.StatusBadRequest
+	case CodeUnauthorized:
+		return http.StatusUnauthorized
+	case CodeForbidden:
+		return http.StatusForbidden
+	case CodeNotFound, CodeMarketNotFound, CodeOrderNotFound, CodeAccountNotFound:
+		return http.StatusNotFound
+	case CodeDuplicateIdempotencyKey, CodeOrderNotCancellable, CodeInvalidStatusTransition, CodeMarketClosed:
+		return http.StatusConflict
+	case CodeInsufficientBalance, CodeWithdrawLimit:
+		return http.StatusUnprocessableEntity
+	case CodeRateLimited:
+		return http.StatusTooManyRequests
+	case CodeVenueRejected:
+		return http.StatusBadGateway
+	case CodeVenueUnavailable:
+		return http.StatusServiceUnavailable
+	case CodeVenueTimeout:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/predictpaul/common
 
-go 1.21
+go 1.23
 
 require github.com/shopspring/decimal v1.4.0
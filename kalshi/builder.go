@@ -0,0 +1,264 @@
+package kalshi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/predictpaul/common"
+)
+
+// ErrDuplicateClientOrderID is returned when a client order ID has already been used.
+var ErrDuplicateClientOrderID = errors.New("kalshi: duplicate client_order_id")
+
+// MaxClientOrderIDLength is the longest client_order_id accepted.
+const MaxClientOrderIDLength = 64
+
+var clientOrderIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// IsValidClientOrderID returns whether id is 1-64 letters, digits, '-' or '_'.
+// UUIDs are valid client order IDs.
+func IsValidClientOrderID(id string) bool {
+	return len(id) <= MaxClientOrderIDLength && clientOrderIDPattern.MatchString(id)
+}
+
+// ClientOrderIDs records the client order IDs already used so that
+// duplicates are rejected before they reach the exchange.
+type ClientOrderIDs map[string]struct{}
+
+// Reserve records id, returning ErrDuplicateClientOrderID if it was already used.
+func (s ClientOrderIDs) Reserve(id string) error {
+	if _, ok := s[id]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateClientOrderID, id)
+	}
+	s[id] = struct{}{}
+	return nil
+}
+
+// Validate checks p against the Kalshi order rules and returns a
+// common.ValidationErrors listing every violation, or nil:
+//   - ticker, side and action are set; type is limit, market or empty (limit)
+//   - at most one of yes_price / no_price is set, and limit orders set exactly one
+//   - prices are 1-99 cents and a multiple of m.TickSize
+//   - limit orders and sells have a positive count; market buys have
+//     buy_max_cost or a count, and buy_max_cost is only used by buys
+//   - expiration_ts is only used with good_till_canceled (or no time in force),
+//     is after now, and market orders are not good_till_canceled
+//   - client_order_id, when set, is well-formed
+//
+// m is optional; when set the ticker must match and the market must be
+// tradable. A zero now skips the expiration time check.
+func (p *CreateOrderParams) Validate(m *Market, now time.Time) error {
+	var errs common.ValidationErrors
+	validateOrderTarget(&errs, p.Ticker, p.Side, p.Action, m)
+	isMarket := p.Type == OrderTypeMarket
+	if p.Type != "" && p.Type != OrderTypeLimit && !isMarket {
+		errs.Add("type", fmt.Sprintf("must be %s or %s", OrderTypeLimit, OrderTypeMarket))
+	}
+
+	validatePrices(&errs, p.YesPrice, p.NoPrice, !isMarket, m)
+
+	if p.Count < 0 {
+		errs.Add("count", "must not be negative")
+	}
+	if p.BuyMaxCost < 0 {
+		errs.Add("buy_max_cost", "must not be negative")
+	}
+	switch {
+	case p.Action == ActionSell && p.BuyMaxCost > 0:
+		errs.Add("buy_max_cost", "is only allowed on buy orders")
+	case isMarket && p.Action == ActionBuy && p.Count <= 0 && p.BuyMaxCost <= 0:
+		errs.Add("buy_max_cost", "market buys need buy_max_cost or count")
+	case (!isMarket || p.Action == ActionSell) && p.Count == 0:
+		errs.Add("count", "is required")
+	}
+
+	switch p.TimeInForce {
+	case "", TIFGoodTilCanceled:
+		if isMarket && p.TimeInForce == TIFGoodTilCanceled {
+			errs.Add("time_in_force", "market orders cannot rest on the book")
+		}
+	case TIFFillOrKill, TIFImmediateOrCancel:
+		if p.ExpirationTS != 0 {
+			errs.Add("expiration_ts", fmt.Sprintf("cannot be set with %s", p.TimeInForce))
+		}
+	default:
+		errs.Add("time_in_force", fmt.Sprintf("unknown time in force %q", p.TimeInForce))
+	}
+	if p.ExpirationTS < 0 {
+		errs.Add("expiration_ts", "must not be negative")
+	} else if p.ExpirationTS > 0 && !now.IsZero() && p.ExpirationTS <= now.Unix() {
+		errs.Add("expiration_ts", "must be in the future")
+	}
+	if p.ExpirationTS > 0 && isMarket {
+		errs.Add("expiration_ts", "cannot be set on market orders")
+	}
+
+	if p.ClientOrderID != "" && !IsValidClientOrderID(p.ClientOrderID) {
+		errs.Add("client_order_id", fmt.Sprintf("must be 1-%d letters, digits, '-' or '_'", MaxClientOrderIDLength))
+	}
+	return errs.Err()
+}
+
+// validateOrderTarget checks the ticker, side and action of an order against m (optional).
+func validateOrderTarget(errs *common.ValidationErrors, ticker string, side Side, action Action, m *Market) {
+	if ticker == "" {
+		errs.Add("ticker", "is required")
+	}
+	if m != nil {
+		if ticker != "" && ticker != m.Ticker {
+			errs.Add("ticker", fmt.Sprintf("does not match market %s", m.Ticker))
+		}
+		if !m.IsTradable() {
+			errs.Add("ticker", fmt.Sprintf("market is %s", m.Status))
+		}
+	}
+	if side != SideYes && side != SideNo {
+		errs.Add("side", fmt.Sprintf("must be %s or %s", SideYes, SideNo))
+	}
+	if action != ActionBuy && action != ActionSell {
+		errs.Add("action", fmt.Sprintf("must be %s or %s", ActionBuy, ActionSell))
+	}
+}
+
+// validatePrices checks that at most one of yes / no is set (exactly one when
+// required) and that it is 1-99 cents on the tick size of m (optional).
+func validatePrices(errs *common.ValidationErrors, yes, no int, required bool, m *Market) {
+	switch {
+	case yes != 0 && no != 0:
+		errs.Add("no_price", "only one of yes_price and no_price may be set")
+	case yes == 0 && no == 0 && required:
+		errs.Add("yes_price", "yes_price or no_price is required")
+	}
+	tick := 1
+	if m != nil && m.TickSize > 0 {
+		tick = m.TickSize
+	}
+	for _, f := range []struct {
+		field string
+		price int
+	}{{"yes_price", yes}, {"no_price", no}} {
+		switch {
+		case f.price == 0:
+		case f.price < 1 || f.price > 99:
+			errs.Add(f.field, "must be between 1 and 99 cents")
+		case f.price%tick != 0:
+			errs.Add(f.field, fmt.Sprintf("must be a multiple of the tick size %d", tick))
+		}
+	}
+}
+
+// OrderBuilder builds CreateOrderParams and validates them on Build.
+//
+//	params, err := kalshi.NewOrderBuilder("KXBTC-25").
+//		Buy(kalshi.SideYes).YesPrice(42).Count(10).
+//		ForMarket(market).UniqueIn(ids).
+//		Build()
+type OrderBuilder struct {
+	params CreateOrderParams
+	market *Market
+	ids    ClientOrderIDs
+	now    func() time.Time
+}
+
+// NewOrderBuilder returns a builder for a limit order on ticker.
+func NewOrderBuilder(ticker string) *OrderBuilder {
+	return &OrderBuilder{
+		params: CreateOrderParams{Ticker: ticker, Type: OrderTypeLimit},
+		now:    time.Now,
+	}
+}
+
+// Buy sets the action to buy on side.
+func (b *OrderBuilder) Buy(side Side) *OrderBuilder {
+	b.params.Action, b.params.Side = ActionBuy, side
+	return b
+}
+
+// Sell sets the action to sell on side.
+func (b *OrderBuilder) Sell(side Side) *OrderBuilder {
+	b.params.Action, b.params.Side = ActionSell, side
+	return b
+}
+
+// YesPrice sets the limit price in cents on the YES side.
+func (b *OrderBuilder) YesPrice(cents int) *OrderBuilder {
+	b.params.YesPrice = cents
+	return b
+}
+
+// NoPrice sets the limit price in cents on the NO side.
+func (b *OrderBuilder) NoPrice(cents int) *OrderBuilder {
+	b.params.NoPrice = cents
+	return b
+}
+
+// Count sets the number of contracts.
+func (b *OrderBuilder) Count(n int) *OrderBuilder {
+	b.params.Count = n
+	return b
+}
+
+// MarketOrder turns the order into a market order.
+func (b *OrderBuilder) MarketOrder() *OrderBuilder {
+	b.params.Type = OrderTypeMarket
+	return b
+}
+
+// BuyMaxCost sets the maximum cost in cents of a market buy.
+func (b *OrderBuilder) BuyMaxCost(cents int) *OrderBuilder {
+	b.params.BuyMaxCost = cents
+	return b
+}
+
+// TimeInForce sets the time-in-force policy.
+func (b *OrderBuilder) TimeInForce(tif TimeInForce) *OrderBuilder {
+	b.params.TimeInForce = tif
+	return b
+}
+
+// ExpiresAt sets the expiration time of a resting order.
+func (b *OrderBuilder) ExpiresAt(t time.Time) *OrderBuilder {
+	b.params.ExpirationTS = t.Unix()
+	return b
+}
+
+// ClientOrderID sets the client order ID.
+func (b *OrderBuilder) ClientOrderID(id string) *OrderBuilder {
+	b.params.ClientOrderID = id
+	return b
+}
+
+// ForMarket validates the order against m: ticker, status and tick size.
+func (b *OrderBuilder) ForMarket(m *Market) *OrderBuilder {
+	b.market = m
+	return b
+}
+
+// UniqueIn rejects a client order ID already reserved in ids, and reserves it
+// when the order is built successfully.
+func (b *OrderBuilder) UniqueIn(ids ClientOrderIDs) *OrderBuilder {
+	b.ids = ids
+	return b
+}
+
+// Clock sets the time source used to check the expiration time.
+func (b *OrderBuilder) Clock(now func() time.Time) *OrderBuilder {
+	b.now = now
+	return b
+}
+
+// Build validates the order and returns its params. Errors are a
+// common.ValidationErrors or wrap ErrDuplicateClientOrderID.
+func (b *OrderBuilder) Build() (CreateOrderParams, error) {
+	if err := b.params.Validate(b.market, b.now()); err != nil {
+		return CreateOrderParams{}, err
+	}
+	if b.ids != nil && b.params.ClientOrderID != "" {
+		if err := b.ids.Reserve(b.params.ClientOrderID); err != nil {
+			return CreateOrderParams{}, err
+		}
+	}
+	return b.params, nil
+}
@@ -0,0 +1,231 @@
+package kalshi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Candlestick period intervals accepted by the Kalshi API, in minutes.
+const (
+	PeriodMinute = 1
+	PeriodHour   = 60
+	PeriodDay    = 1440
+)
+
+// ErrTickOutOfOrder is returned by CandleAggregator.Add for a tick older than the open candle.
+var ErrTickOutOfOrder = errors.New("kalshi: tick before the current candle")
+
+// OHLC holds open, high, low and close prices in cents.
+type OHLC struct {
+	Open  int `json:"open"`
+	High  int `json:"high"`
+	Low   int `json:"low"`
+	Close int `json:"close"`
+}
+
+// PriceOHLC holds the traded YES prices of a period in cents. The fields are
+// nil when nothing traded; Previous is the last close before the period.
+type PriceOHLC struct {
+	Open     *int `json:"open"`
+	High     *int `json:"high"`
+	Low      *int `json:"low"`
+	Close    *int `json:"close"`
+	Mean     *int `json:"mean"`
+	Previous *int `json:"previous"`
+}
+
+// Candlestick is one period of market history.
+type Candlestick struct {
+	EndPeriodTS  int64     `json:"end_period_ts"` // unix seconds, end of the period
+	YesBid       OHLC      `json:"yes_bid"`
+	YesAsk       OHLC      `json:"yes_ask"`
+	Price        PriceOHLC `json:"price"`
+	Volume       int64     `json:"volume"`
+	OpenInterest int64     `json:"open_interest"`
+}
+
+// EndTime returns EndPeriodTS as a time.
+func (c *Candlestick) EndTime() time.Time {
+	return time.Unix(c.EndPeriodTS, 0).UTC()
+}
+
+// HasTrades reports whether anything traded in the period.
+func (c *Candlestick) HasTrades() bool {
+	return c.Price.Close != nil
+}
+
+// CandlesticksResponse is the response of GET /series/{series_ticker}/markets/{ticker}/candlesticks.
+type CandlesticksResponse struct {
+	Ticker       string        `json:"ticker"`
+	Candlesticks []Candlestick `json:"candlesticks"`
+}
+
+// GetCandlesticks returns the candles of ticker between start and end with a
+// period of periodMinutes (PeriodMinute, PeriodHour or PeriodDay).
+func (c *Client) GetCandlesticks(ctx context.Context, seriesTicker, ticker string, start, end time.Time, periodMinutes int) (*CandlesticksResponse, error) {
+	q := url.Values{}
+	q.Set("start_ts", strconv.FormatInt(start.Unix(), 10))
+	q.Set("end_ts", strconv.FormatInt(end.Unix(), 10))
+	q.Set("period_interval", strconv.Itoa(periodMinutes))
+	path := "/series/" + url.PathEscape(seriesTicker) + "/markets/" + url.PathEscape(ticker) + "/candlesticks"
+	var out CandlesticksResponse
+	if err := c.do(ctx, http.MethodGet, path, q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Tick is a single trade used to build candles: the YES price in cents and
+// the number of contracts traded.
+type Tick struct {
+	Time     time.Time `json:"time"`
+	YesPrice int       `json:"yes_price"`
+	Count    int       `json:"count"`
+}
+
+// Tick returns the fill as a Tick.
+func (f *Fill) Tick() Tick {
+	return Tick{Time: f.CreatedTime, YesPrice: f.YesPrice, Count: f.Count}
+}
+
+// Tick returns the trade as a Tick.
+func (u *TradeUpdate) Tick() Tick {
+	return Tick{Time: time.Unix(u.TS, 0).UTC(), YesPrice: u.YesPrice, Count: u.Count}
+}
+
+// CandleAggregator builds candles of a fixed interval from ticks in time
+// order. Periods are aligned to the interval since the unix epoch, run from
+// just after their start up to and including their end, and each candle is
+// stamped with the end of its period, as Kalshi does. Periods
+// without trades between two traded periods are emitted with a nil Price and
+// Previous set. Trades carry no quotes or open interest, so YesBid, YesAsk and
+// OpenInterest are left zero.
+type CandleAggregator struct {
+	interval time.Duration
+	current  *candleState
+	previous *int // last close
+}
+
+type candleState struct {
+	end    int64
+	candle Candlestick
+	volume int64 // sum of price * count, for the mean
+}
+
+// NewCandleAggregator returns an aggregator for interval, which must be a
+// whole number of seconds.
+func NewCandleAggregator(interval time.Duration) (*CandleAggregator, error) {
+	if interval < time.Second || interval%time.Second != 0 {
+		return nil, fmt.Errorf("kalshi: candle interval %s is not a whole number of seconds", interval)
+	}
+	return &CandleAggregator{interval: interval}, nil
+}
+
+// periodEnd returns the end of the period containing t, in unix seconds.
+// Periods include their end, so a tick on a boundary closes the period ending
+// at it.
+func (a *CandleAggregator) periodEnd(t time.Time) int64 {
+	step := int64(a.interval / time.Second)
+	secs := t.Unix()
+	if t.Nanosecond() > 0 {
+		secs++ // after the whole second
+	}
+	return ((secs + step - 1) / step) * step
+}
+
+// Add adds a tick and returns the candles completed by it, oldest first.
+// Ticks older than the open candle return ErrTickOutOfOrder.
+func (a *CandleAggregator) Add(t Tick) ([]Candlestick, error) {
+	end := a.periodEnd(t.Time)
+	var done []Candlestick
+	if a.current != nil {
+		switch {
+		case end < a.current.end:
+			return nil, fmt.Errorf("%w: %s", ErrTickOutOfOrder, t.Time.Format(time.RFC3339))
+		case end > a.current.end:
+			done = append(done, a.close())
+			step := int64(a.interval / time.Second)
+			for gap := done[0].EndPeriodTS + step; gap < end; gap += step {
+				done = append(done, Candlestick{EndPeriodTS: gap, Price: PriceOHLC{Previous: intPtr(a.previous)}})
+			}
+		}
+	}
+	if a.current == nil {
+		a.current = &candleState{end: end, candle: Candlestick{
+			EndPeriodTS: end,
+			Price:       PriceOHLC{Previous: intPtr(a.previous)},
+		}}
+	}
+
+	c := &a.current.candle
+	price := t.YesPrice
+	if c.Price.Open == nil {
+		c.Price.Open, c.Price.High, c.Price.Low = intPtr(&price), intPtr(&price), intPtr(&price)
+	}
+	if price > *c.Price.High {
+		*c.Price.High = price
+	}
+	if price < *c.Price.Low {
+		*c.Price.Low = price
+	}
+	c.Price.Close = intPtr(&price)
+	c.Volume += int64(t.Count)
+	a.current.volume += int64(price) * int64(t.Count)
+	return done, nil
+}
+
+// Flush returns the open candle, if any, and resets it. The last close is
+// kept as Previous for later ticks.
+func (a *CandleAggregator) Flush() []Candlestick {
+	if a.current == nil {
+		return nil
+	}
+	return []Candlestick{a.close()}
+}
+
+// close finishes the open candle.
+func (a *CandleAggregator) close() Candlestick {
+	s := a.current
+	a.current = nil
+	if s.candle.Volume > 0 {
+		mean := int((s.volume + s.candle.Volume/2) / s.candle.Volume)
+		s.candle.Price.Mean = &mean
+	} else if s.candle.Price.Close != nil {
+		s.candle.Price.Mean = intPtr(s.candle.Price.Close)
+	}
+	if s.candle.Price.Close != nil {
+		a.previous = intPtr(s.candle.Price.Close)
+	}
+	return s.candle
+}
+
+// AggregateCandles builds candles of interval from ticks sorted by time.
+func AggregateCandles(ticks []Tick, interval time.Duration) ([]Candlestick, error) {
+	a, err := NewCandleAggregator(interval)
+	if err != nil {
+		return nil, err
+	}
+	var out []Candlestick
+	for _, t := range ticks {
+		done, err := a.Add(t)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, done...)
+	}
+	return append(out, a.Flush()...), nil
+}
+
+// intPtr returns a copy of *p, or nil.
+func intPtr(p *int) *int {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
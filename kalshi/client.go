@@ -0,0 +1,355 @@
+package kalshi
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the Kalshi trade API root.
+const DefaultBaseURL = "https://api.elections.kalshi.com/trade-api/v2"
+
+// Authentication headers.
+const (
+	HeaderAccessKey       = "KALSHI-ACCESS-KEY"
+	HeaderAccessSignature = "KALSHI-ACCESS-SIGNATURE"
+	HeaderAccessTimestamp = "KALSHI-ACCESS-TIMESTAMP"
+)
+
+// ErrInvalidPrivateKey is returned by ParsePrivateKeyPEM for a key that is not RSA.
+var ErrInvalidPrivateKey = errors.New("kalshi: invalid RSA private key")
+
+// APIError is a non-2xx response from the Kalshi API.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Details    string `json:"details,omitempty"`
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	switch {
+	case e.StatusCode == 0:
+		// Per-item error of a batch response.
+		return fmt.Sprintf("kalshi: %s: %s", e.Code, msg)
+	case e.Code != "":
+		return fmt.Sprintf("kalshi: %d %s: %s", e.StatusCode, e.Code, msg)
+	default:
+		return fmt.Sprintf("kalshi: %d: %s", e.StatusCode, msg)
+	}
+}
+
+// ParsePrivateKeyPEM parses a PEM encoded RSA private key in PKCS#1 or PKCS#8 form,
+// as downloaded from the Kalshi API keys page.
+func ParsePrivateKeyPEM(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%w: no PEM block", ErrInvalidPrivateKey)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrInvalidPrivateKey, parsed)
+	}
+	return key, nil
+}
+
+// Sign returns the base64 encoded RSA-PSS (SHA-256, salt length equal to the
+// hash) signature of timestamp + method + path. timestamp is in milliseconds
+// and path is the escaped URL path as sent, including /trade-api/v2 and
+// excluding the query.
+func Sign(key *rsa.PrivateKey, timestamp, method, path string) (string, error) {
+	digest := sha256.Sum256([]byte(timestamp + method + path))
+	sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:],
+		&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// Client is a Kalshi REST API client. Requests are signed with PrivateKey
+// under KeyID. BaseURL, HTTPClient and Now may be replaced, e.g. to point the
+// client at an httptest.Server.
+type Client struct {
+	BaseURL    string
+	KeyID      string
+	PrivateKey *rsa.PrivateKey
+	HTTPClient *http.Client
+	Now        func() time.Time
+}
+
+// NewClient returns a client for DefaultBaseURL.
+func NewClient(keyID string, key *rsa.PrivateKey) *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		KeyID:      keyID,
+		PrivateKey: key,
+		HTTPClient: http.DefaultClient,
+		Now:        time.Now,
+	}
+}
+
+// MarketsResponse is the response of GET /markets.
+type MarketsResponse struct {
+	Markets []Market `json:"markets"`
+	Cursor  string   `json:"cursor"`
+}
+
+// OrdersResponse is the response of GET /portfolio/orders.
+type OrdersResponse struct {
+	Orders []Order `json:"orders"`
+	Cursor string  `json:"cursor"`
+}
+
+// PositionsResponse is the response of GET /portfolio/positions.
+type PositionsResponse struct {
+	MarketPositions []Position `json:"market_positions"`
+	Cursor          string     `json:"cursor"`
+}
+
+// FillsResponse is the response of GET /portfolio/fills.
+type FillsResponse struct {
+	Fills  []Fill `json:"fills"`
+	Cursor string `json:"cursor"`
+}
+
+// SettlementsResponse is the response of GET /portfolio/settlements.
+type SettlementsResponse struct {
+	Settlements []Settlement `json:"settlements"`
+	Cursor      string       `json:"cursor"`
+}
+
+// CancelOrderResponse is the response of DELETE /portfolio/orders/{order_id}.
+type CancelOrderResponse struct {
+	Order     Order `json:"order"`
+	ReducedBy int   `json:"reduced_by"`
+}
+
+// ListMarkets lists markets. params may be nil.
+func (c *Client) ListMarkets(ctx context.Context, params *ListMarketsParams) (*MarketsResponse, error) {
+	var q url.Values
+	if params != nil {
+		q = params.Values()
+	}
+	var out MarketsResponse
+	if err := c.do(ctx, http.MethodGet, "/markets", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMarket returns the market with the given ticker.
+func (c *Client) GetMarket(ctx context.Context, ticker string) (*Market, error) {
+	var out struct {
+		Market Market `json:"market"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/markets/"+url.PathEscape(ticker), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Market, nil
+}
+
+// GetOrderbook returns the orderbook of ticker. depth limits the number of
+// levels per side; 0 returns all levels.
+func (c *Client) GetOrderbook(ctx context.Context, ticker string, depth int) (*Orderbook, error) {
+	q := url.Values{}
+	if depth > 0 {
+		q.Set("depth", strconv.Itoa(depth))
+	}
+	var out struct {
+		Orderbook Orderbook `json:"orderbook"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/markets/"+url.PathEscape(ticker)+"/orderbook", q, nil, &out); err != nil {
+		return nil, err
+	}
+	if out.Orderbook.Ticker == "" {
+		out.Orderbook.Ticker = ticker
+	}
+	return &out.Orderbook, nil
+}
+
+// CreateOrder places an order. Use OrderBuilder or CreateOrderParams.Validate
+// to catch rule violations before they reach the exchange.
+func (c *Client) CreateOrder(ctx context.Context, params *CreateOrderParams) (*Order, error) {
+	var out struct {
+		Order Order `json:"order"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/portfolio/orders", nil, params, &out); err != nil {
+		return nil, err
+	}
+	return &out.Order, nil
+}
+
+// CancelOrder cancels the resting part of an order.
+func (c *Client) CancelOrder(ctx context.Context, orderID string) (*CancelOrderResponse, error) {
+	var out CancelOrderResponse
+	if err := c.do(ctx, http.MethodDelete, "/portfolio/orders/"+url.PathEscape(orderID), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListOrders lists the user's orders. params may be nil.
+func (c *Client) ListOrders(ctx context.Context, params *ListOrdersParams) (*OrdersResponse, error) {
+	var q url.Values
+	if params != nil {
+		q = params.Values()
+	}
+	var out OrdersResponse
+	if err := c.do(ctx, http.MethodGet, "/portfolio/orders", q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListPositions lists the user's market positions. params may be nil.
+func (c *Client) ListPositions(ctx context.Context, params *ListParams) (*PositionsResponse, error) {
+	var out PositionsResponse
+	if err := c.do(ctx, http.MethodGet, "/portfolio/positions", optionalListQuery(params), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListFills lists the user's fills. params may be nil.
+func (c *Client) ListFills(ctx context.Context, params *ListParams) (*FillsResponse, error) {
+	var out FillsResponse
+	if err := c.do(ctx, http.MethodGet, "/portfolio/fills", optionalListQuery(params), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListSettlements lists the user's settlements. params may be nil.
+func (c *Client) ListSettlements(ctx context.Context, params *ListParams) (*SettlementsResponse, error) {
+	var out SettlementsResponse
+	if err := c.do(ctx, http.MethodGet, "/portfolio/settlements", optionalListQuery(params), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBalance returns the user's balance.
+func (c *Client) GetBalance(ctx context.Context) (*Balance, error) {
+	var out Balance
+	if err := c.do(ctx, http.MethodGet, "/portfolio/balance", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func optionalListQuery(p *ListParams) url.Values {
+	if p == nil {
+		return nil
+	}
+	return p.Values()
+}
+
+// do sends a signed request for path (relative to BaseURL) and decodes the
+// JSON response into out. Non-2xx responses are returned as *APIError.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	u, err := url.Parse(strings.TrimRight(c.BaseURL, "/") + path)
+	if err != nil {
+		return err
+	}
+	if len(query) > 0 {
+		u.RawQuery = query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if err := c.sign(req); err != nil {
+		return err
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		var envelope struct {
+			Error *APIError `json:"error"`
+		}
+		data, _ := io.ReadAll(resp.Body)
+		if json.Unmarshal(data, &envelope) == nil && envelope.Error != nil {
+			apiErr = envelope.Error
+			apiErr.StatusCode = resp.StatusCode
+		} else {
+			apiErr.Message = strings.TrimSpace(string(data))
+		}
+		return apiErr
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// sign sets the authentication headers on req. Requests are sent unsigned
+// when the client has no key.
+func (c *Client) sign(req *http.Request) error {
+	if c.PrivateKey == nil {
+		return nil
+	}
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+	ts := strconv.FormatInt(now().UnixMilli(), 10)
+	sig, err := Sign(c.PrivateKey, ts, req.Method, req.URL.EscapedPath())
+	if err != nil {
+		return err
+	}
+	req.Header.Set(HeaderAccessKey, c.KeyID)
+	req.Header.Set(HeaderAccessTimestamp, ts)
+	req.Header.Set(HeaderAccessSignature, sig)
+	return nil
+}
@@ -0,0 +1,66 @@
+package kalshi
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClientSignsRequests(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.UnixMilli(1700000000123)
+	const ticker = "KX BTC/25"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get(HeaderAccessKey); got != "key-id" {
+			t.Errorf("%s = %q, want %q", HeaderAccessKey, got, "key-id")
+		}
+		ts := r.Header.Get(HeaderAccessTimestamp)
+		if ts != "1700000000123" {
+			t.Errorf("%s = %q, want %q", HeaderAccessTimestamp, ts, "1700000000123")
+		}
+		path := r.URL.EscapedPath()
+		if want := "/trade-api/v2/markets/KX%20BTC%2F25"; path != want {
+			t.Errorf("path = %q, want %q", path, want)
+		}
+		sig, err := base64.StdEncoding.DecodeString(r.Header.Get(HeaderAccessSignature))
+		if err != nil {
+			t.Errorf("decode signature: %v", err)
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+		digest := sha256.Sum256([]byte(ts + r.Method + path))
+		if err := rsa.VerifyPSS(&key.PublicKey, crypto.SHA256, digest[:], sig,
+			&rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}); err != nil {
+			t.Errorf("verify signature: %v", err)
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"market":{"ticker":"KX BTC/25"}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("key-id", key)
+	c.BaseURL = srv.URL + "/trade-api/v2"
+	c.HTTPClient = srv.Client()
+	c.Now = func() time.Time { return now }
+
+	m, err := c.GetMarket(context.Background(), ticker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Ticker != ticker {
+		t.Errorf("ticker = %q, want %q", m.Ticker, ticker)
+	}
+}
@@ -0,0 +1,212 @@
+package kalshi
+
+import (
+	"github.com/predictpaul/common"
+	"github.com/predictpaul/common/service"
+	"github.com/shopspring/decimal"
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// CentsToDollars converts an amount in cents to dollars.
+func CentsToDollars(cents int64) decimal.Decimal {
+	return decimal.New(cents, -2)
+}
+
+// DollarsToCents converts a dollar amount to cents, rounding to the nearest cent.
+func DollarsToCents(dollars decimal.Decimal) int64 {
+	return dollars.Mul(hundred).Round(0).IntPart()
+}
+
+// MarketSide returns the common MarketSide (YES / NO) for s, or "" if unknown.
+func (s Side) MarketSide() string {
+	switch s {
+	case SideYes:
+		return common.MarketSideYES
+	case SideNo:
+		return common.MarketSideNO
+	default:
+		return ""
+	}
+}
+
+// OrderDirection returns the common OrderDirection (BUY / SELL) for a, or "" if unknown.
+func (a Action) OrderDirection() string {
+	switch a {
+	case ActionBuy:
+		return common.OrderDirectionBUY
+	case ActionSell:
+		return common.OrderDirectionSELL
+	default:
+		return ""
+	}
+}
+
+// CommonOrderType returns the common OrderType (LIMIT / MARKET) for t, or "" if unknown.
+func (t OrderType) CommonOrderType() string {
+	switch t {
+	case OrderTypeLimit:
+		return common.OrderTypeLimit
+	case OrderTypeMarket:
+		return common.OrderTypeMarket
+	default:
+		return ""
+	}
+}
+
+// CommonStatus maps s to the common order lifecycle given the filled contract
+// count, which may be fractional (see Order.TotalFillCountDecimal).
+//   - pending -> SUBMITTING
+//   - resting -> PENDING, or PARTIAL_FILLED once anything has filled
+//   - executed -> FILLED
+//   - canceled -> CANCELLED, even when partially filled; the fill is kept in
+//     the order's filled cost and shares
+func (s OrderStatus) CommonStatus(fillCount decimal.Decimal) common.OrderStatus {
+	switch s {
+	case OrderStatusPending:
+		return common.OrderStatusSubmitting
+	case OrderStatusResting:
+		if fillCount.IsPositive() {
+			return common.OrderStatusPartialFilled
+		}
+		return common.OrderStatusPending
+	case OrderStatusExecuted:
+		return common.OrderStatusFilled
+	case OrderStatusCanceled:
+		return common.OrderStatusCancelled
+	default:
+		return ""
+	}
+}
+
+// APIOrderStatusToCommon maps an APIOrderStatus* value to the common order lifecycle.
+// Resting orders with fills are reported as PARTIAL_FILLED; settled orders as FILLED.
+// Unknown values return "".
+func APIOrderStatusToCommon(status, filledCount int) common.OrderStatus {
+	switch status {
+	case APIOrderStatusPending:
+		return common.OrderStatusSubmitting
+	case APIOrderStatusResting:
+		if filledCount > 0 {
+			return common.OrderStatusPartialFilled
+		}
+		return common.OrderStatusPending
+	case APIOrderStatusFilled, APIOrderStatusSettled:
+		return common.OrderStatusFilled
+	case APIOrderStatusCanceled:
+		return common.OrderStatusCancelled
+	case APIOrderStatusPartiallyFilled:
+		return common.OrderStatusPartialFilled
+	default:
+		return ""
+	}
+}
+
+// sidePrice returns the price in cents for the given side.
+func sidePrice(side Side, yesPrice, noPrice int) int {
+	if side == SideNo {
+		return noPrice
+	}
+	return yesPrice
+}
+
+// avgPrice returns cost / count, or zero when nothing has filled.
+func avgPrice(cost, count decimal.Decimal) decimal.Decimal {
+	if count.IsZero() {
+		return decimal.Zero
+	}
+	return cost.Div(count)
+}
+
+// ToServiceOrderItem converts the order to a unified service.OrderItem.
+// Counts, prices and amounts prefer the *_fp and *_dollars fields (see
+// TotalFillCountDecimal), falling back to the cents integers; taker and maker
+// fills and fees are summed. ID is the ClientOrderID when set, otherwise the
+// Kalshi OrderID; MarketID, MarketOutID and TokenID are the market ticker.
+// Callers override the internal identifiers with their own.
+func (o *Order) ToServiceOrderItem() service.OrderItem {
+	id := o.ClientOrderID
+	if id == "" {
+		id = o.OrderID
+	}
+	limitPrice := o.PriceDecimal()
+	requested := o.InitialCountDecimal()
+	filled := o.TotalFillCountDecimal()
+	filledCost := o.TotalFillCostDecimal()
+	orderTime := o.CreatedTime
+
+	return service.OrderItem{
+		ID:              id,
+		OrderTime:       &orderTime,
+		MarketType:      common.MarketTypeKalshi,
+		MarketID:        o.Ticker,
+		MarketOutID:     o.Ticker,
+		MarketSide:      o.Side.MarketSide(),
+		MarketOrderID:   o.OrderID,
+		TokenID:         o.Ticker,
+		TokenAmount:     filledCost,
+		RequestedAmount: limitPrice.Mul(requested),
+		OrderDirection:  o.Action.OrderDirection(),
+		OrderType:       o.Type.CommonOrderType(),
+		LimitPrice:      limitPrice,
+		RequestedShares: requested,
+		SharesAmount:    filled,
+		FilledCost:      filledCost,
+		FilledPrice:     avgPrice(filledCost, filled),
+		FeesPaid:        o.TotalFeesDecimal(),
+		Status:          string(o.Status.CommonStatus(filled)),
+		CreatedAt:       o.CreatedTime,
+		UpdatedAt:       o.LastUpdateTime,
+	}
+}
+
+// ToOrderItem converts the order to a unified common.OrderItem.
+// See ToServiceOrderItem for the field mapping.
+func (o *Order) ToOrderItem() common.OrderItem {
+	item := o.ToServiceOrderItem()
+	return item.ToCommon()
+}
+
+// ToServiceOrderItem converts the internal API order to a unified service.OrderItem.
+// Amounts are converted from cents to dollars. ID and MarketOrderID are the
+// OrderID, UserWallet is the UserID, and MarketID, MarketOutID and TokenID are
+// the market ticker.
+func (o *OrderResponse) ToServiceOrderItem() service.OrderItem {
+	side := Side(o.Side)
+	limitPrice := CentsToDollars(int64(sidePrice(side, o.YesPrice, o.NoPrice)))
+	requested := decimal.NewFromInt(int64(o.Count))
+	filled := decimal.NewFromInt(int64(o.FilledCount))
+	filledCost := CentsToDollars(int64(o.FilledCost))
+	orderTime := o.CreatedAt
+
+	return service.OrderItem{
+		ID:              o.OrderID,
+		UserWallet:      o.UserID,
+		OrderTime:       &orderTime,
+		MarketType:      common.MarketTypeKalshi,
+		MarketID:        o.Ticker,
+		MarketOutID:     o.Ticker,
+		MarketSide:      side.MarketSide(),
+		MarketOrderID:   o.OrderID,
+		TokenID:         o.Ticker,
+		TokenAmount:     filledCost,
+		RequestedAmount: limitPrice.Mul(requested),
+		OrderDirection:  Action(o.Action).OrderDirection(),
+		OrderType:       OrderType(o.OrderType).CommonOrderType(),
+		LimitPrice:      limitPrice,
+		RequestedShares: requested,
+		SharesAmount:    filled,
+		FilledCost:      filledCost,
+		FilledPrice:     avgPrice(filledCost, filled),
+		FeesPaid:        CentsToDollars(int64(o.FeesPaid)),
+		Status:          string(APIOrderStatusToCommon(o.Status, o.FilledCount)),
+		CreatedAt:       o.CreatedAt,
+		UpdatedAt:       o.UpdatedAt,
+	}
+}
+
+// ToOrderItem converts the internal API order to a unified common.OrderItem.
+func (o *OrderResponse) ToOrderItem() common.OrderItem {
+	item := o.ToServiceOrderItem()
+	return item.ToCommon()
+}
@@ -0,0 +1,206 @@
+package kalshi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/predictpaul/common/service"
+	"github.com/shopspring/decimal"
+)
+
+// ErrNotMutuallyExclusive is returned when a probability sum is requested for
+// an event whose markets are not mutually exclusive.
+var ErrNotMutuallyExclusive = errors.New("kalshi: event is not mutually exclusive")
+
+// Event groups the markets of one question, e.g. the brackets of a CPI print.
+type Event struct {
+	EventTicker       string     `json:"event_ticker"`
+	SeriesTicker      string     `json:"series_ticker"`
+	SubTitle          string     `json:"sub_title"`
+	Title             string     `json:"title"`
+	Category          string     `json:"category"`
+	MutuallyExclusive bool       `json:"mutually_exclusive"` // at most one market resolves YES
+	StrikeDate        *time.Time `json:"strike_date,omitempty"`
+	StrikePeriod      string     `json:"strike_period,omitempty"`
+	Markets           []Market   `json:"markets,omitempty"` // set when fetched with nested markets
+}
+
+// Series fee types.
+const (
+	FeeTypeQuadratic              = "quadratic"
+	FeeTypeQuadraticWithMakerFees = "quadratic_with_maker_fees"
+	FeeTypeFlat                   = "flat"
+)
+
+// SettlementSource is a source a series settles on.
+type SettlementSource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Series is a recurring family of events, e.g. the daily S&P 500 close.
+type Series struct {
+	Ticker            string             `json:"ticker"`
+	Frequency         string             `json:"frequency"`
+	Title             string             `json:"title"`
+	Category          string             `json:"category"`
+	Tags              []string           `json:"tags"`
+	SettlementSources []SettlementSource `json:"settlement_sources"`
+	FeeType           string             `json:"fee_type"`
+	FeeMultiplier     float64            `json:"fee_multiplier"`
+}
+
+// FeeSchedule returns the fee schedule of the series: DefaultFeeSchedule
+// scaled by FeeMultiplier, plus MakerFeeRate scaled likewise for the
+// quadratic_with_maker_fees fee type. It returns false for fee types that are
+// not quadratic.
+func (s *Series) FeeSchedule() (FeeSchedule, bool) {
+	multiplier := decimal.NewFromFloat(s.FeeMultiplier)
+	if s.FeeMultiplier == 0 {
+		multiplier = decimal.NewFromInt(1)
+	}
+	switch s.FeeType {
+	case FeeTypeQuadratic:
+		return FeeSchedule{TakerRate: DefaultFeeSchedule.TakerRate.Mul(multiplier), MakerRate: decimal.Zero}, true
+	case FeeTypeQuadraticWithMakerFees:
+		return FeeSchedule{
+			TakerRate: DefaultFeeSchedule.TakerRate.Mul(multiplier),
+			MakerRate: MakerFeeRate.Mul(multiplier),
+		}, true
+	default:
+		return FeeSchedule{}, false
+	}
+}
+
+// UnifiedStatus returns the unified status of the series from the events
+// listed under it. See AggregateStatus.
+func (s *Series) UnifiedStatus(events []Event) service.UnifiedMarketStatus {
+	statuses := make([]service.UnifiedMarketStatus, len(events))
+	for i := range events {
+		statuses[i] = events[i].UnifiedStatus()
+	}
+	return AggregateStatus(statuses)
+}
+
+// UnifiedStatus returns the unified status of the event from its markets.
+// See AggregateStatus.
+func (e *Event) UnifiedStatus() service.UnifiedMarketStatus {
+	statuses := make([]service.UnifiedMarketStatus, len(e.Markets))
+	for i := range e.Markets {
+		statuses[i] = e.Markets[i].UnifiedStatus()
+	}
+	return AggregateStatus(statuses)
+}
+
+// AggregateStatus combines the statuses of a group of markets:
+//   - open if any is open
+//   - disputed if any is disputed
+//   - paused if any is paused
+//   - pending if all are pending, or the group is empty
+//   - settled if all are settled
+//   - closed otherwise
+func AggregateStatus(statuses []service.UnifiedMarketStatus) service.UnifiedMarketStatus {
+	counts := make(map[service.UnifiedMarketStatus]int)
+	for _, s := range statuses {
+		counts[s]++
+	}
+	switch {
+	case counts[service.MarketStatusOpen] > 0:
+		return service.MarketStatusOpen
+	case counts[service.MarketStatusDisputed] > 0:
+		return service.MarketStatusDisputed
+	case counts[service.MarketStatusPaused] > 0:
+		return service.MarketStatusPaused
+	case counts[service.MarketStatusPending] == len(statuses):
+		return service.MarketStatusPending
+	case counts[service.MarketStatusSettled] == len(statuses):
+		return service.MarketStatusSettled
+	default:
+		return service.MarketStatusClosed
+	}
+}
+
+// ProbabilitySum is the sum of the YES prices of a group of markets.
+type ProbabilitySum struct {
+	Sum     decimal.Decimal `json:"sum"`     // dollars
+	Priced  int             `json:"priced"`  // markets with a price
+	Missing []string        `json:"missing"` // tickers without a price
+}
+
+// Deviation returns Sum - 1 in dollars: positive when the prices overround,
+// negative when they underround.
+func (p ProbabilitySum) Deviation() decimal.Decimal {
+	return p.Sum.Sub(oneDollar)
+}
+
+// Within reports whether every market is priced and the sum is within
+// toleranceCents of 100¢.
+func (p ProbabilitySum) Within(toleranceCents int) bool {
+	return len(p.Missing) == 0 && p.Deviation().Abs().LessThanOrEqual(CentsToDollars(int64(toleranceCents)))
+}
+
+// SumYesPrices sums the YES price of each market at src (see Market.MarkPrice).
+// For mutually exclusive markets the sum of asks is normally at or above 100¢
+// and the sum of bids at or below.
+func SumYesPrices(markets []Market, src MarkSource) ProbabilitySum {
+	var p ProbabilitySum
+	for i := range markets {
+		price, ok := markets[i].MarkPrice(SideYes, src)
+		if !ok {
+			p.Missing = append(p.Missing, markets[i].Ticker)
+			continue
+		}
+		p.Sum = p.Sum.Add(price)
+		p.Priced++
+	}
+	return p
+}
+
+// ProbabilitySum sums the YES prices of the event's markets at src. It
+// returns ErrNotMutuallyExclusive for events whose markets may all resolve YES.
+func (e *Event) ProbabilitySum(src MarkSource) (ProbabilitySum, error) {
+	if !e.MutuallyExclusive {
+		return ProbabilitySum{}, ErrNotMutuallyExclusive
+	}
+	return SumYesPrices(e.Markets, src), nil
+}
+
+// GroupByEvent groups markets by EventTicker, keeping their order.
+func GroupByEvent(markets []Market) map[string][]Market {
+	groups := make(map[string][]Market)
+	for _, m := range markets {
+		groups[m.EventTicker] = append(groups[m.EventTicker], m)
+	}
+	return groups
+}
+
+// GetEvent returns the event with its markets.
+func (c *Client) GetEvent(ctx context.Context, eventTicker string) (*Event, error) {
+	q := url.Values{}
+	q.Set("with_nested_markets", "true")
+	var out struct {
+		Event   Event    `json:"event"`
+		Markets []Market `json:"markets"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/events/"+url.PathEscape(eventTicker), q, nil, &out); err != nil {
+		return nil, err
+	}
+	if len(out.Event.Markets) == 0 {
+		out.Event.Markets = out.Markets
+	}
+	return &out.Event, nil
+}
+
+// GetSeries returns the series with the given ticker.
+func (c *Client) GetSeries(ctx context.Context, seriesTicker string) (*Series, error) {
+	var out struct {
+		Series Series `json:"series"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/series/"+url.PathEscape(seriesTicker), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Series, nil
+}
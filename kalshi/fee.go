@@ -0,0 +1,200 @@
+package kalshi
+
+import (
+	"errors"
+
+	"github.com/shopspring/decimal"
+)
+
+// FeeSchedule holds the Kalshi fee rates of a market.
+//
+// Kalshi charges fee = ceil(rate * C * P * (1 - P)) rounded up to the next
+// cent, where C is the contract count and P the price in dollars, computed
+// per trade. Taker fees use TakerRate; resting (maker) fills use MakerRate,
+// which is zero on markets without maker fees.
+type FeeSchedule struct {
+	TakerRate decimal.Decimal
+	MakerRate decimal.Decimal
+}
+
+// Standard Kalshi fee schedules. Most markets charge no maker fees; see
+// MakerFeeRate for those that do.
+var (
+	// DefaultFeeSchedule is the general schedule: 7% taker, no maker fees.
+	DefaultFeeSchedule = FeeSchedule{
+		TakerRate: decimal.RequireFromString("0.07"),
+		MakerRate: decimal.Zero,
+	}
+	// IndexFeeSchedule is the reduced schedule of S&P 500 and Nasdaq-100
+	// markets: 3.5% taker, no maker fees.
+	IndexFeeSchedule = FeeSchedule{
+		TakerRate: decimal.RequireFromString("0.035"),
+		MakerRate: decimal.Zero,
+	}
+	// MakerFeeRate is the maker rate of series with the
+	// quadratic_with_maker_fees fee type (1.75%).
+	MakerFeeRate = decimal.RequireFromString("0.0175")
+)
+
+// ErrFeePriceUnknown is returned when a fee is quoted for an order without a price.
+var ErrFeePriceUnknown = errors.New("kalshi: fee quote needs a price")
+
+// Fee returns the fee in cents for count contracts at priceCents under rate.
+func Fee(rate decimal.Decimal, priceCents, count int) int {
+	if count <= 0 || priceCents <= 0 || priceCents >= 100 {
+		return 0
+	}
+	// rate * C * (p/100) * (1 - p/100) dollars == rate * C * p * (100-p) / 100 cents
+	raw := rate.Mul(decimal.NewFromInt(int64(count) * int64(priceCents) * int64(100-priceCents))).Div(hundred)
+	return int(raw.Ceil().IntPart())
+}
+
+// TakerFee returns the taker fee in cents for count contracts at priceCents.
+func (s FeeSchedule) TakerFee(priceCents, count int) int {
+	return Fee(s.TakerRate, priceCents, count)
+}
+
+// MakerFee returns the maker fee in cents for count contracts at priceCents.
+func (s FeeSchedule) MakerFee(priceCents, count int) int {
+	return Fee(s.MakerRate, priceCents, count)
+}
+
+// FeeQuote is the fee range of an order before submission.
+type FeeQuote struct {
+	PriceCents int `json:"price_cents"`
+	Count      int `json:"count"`
+	TakerFee   int `json:"taker_fee"` // if the whole order executes immediately
+	MakerFee   int `json:"maker_fee"` // if the whole order rests and is filled later
+}
+
+// Quote returns the fee range of a limit order. Market orders have no price
+// to quote from and return ErrFeePriceUnknown; simulate them against the
+// orderbook instead.
+func (s FeeSchedule) Quote(p *CreateOrderParams) (FeeQuote, error) {
+	price := sidePrice(p.Side, p.YesPrice, p.NoPrice)
+	if price <= 0 {
+		return FeeQuote{}, ErrFeePriceUnknown
+	}
+	return FeeQuote{
+		PriceCents: price,
+		Count:      p.Count,
+		TakerFee:   s.TakerFee(price, p.Count),
+		MakerFee:   s.MakerFee(price, p.Count),
+	}, nil
+}
+
+// FillFee returns the fee in cents charged for a fill, by its taker/maker role.
+func (s FeeSchedule) FillFee(f *Fill) int {
+	price := sidePrice(f.Side, f.YesPrice, f.NoPrice)
+	if f.IsTaker {
+		return s.TakerFee(price, f.Count)
+	}
+	return s.MakerFee(price, f.Count)
+}
+
+// FeeReconciliation compares the fees reported for an order with the fees
+// expected from its fills. All amounts are in cents.
+type FeeReconciliation struct {
+	OrderID string `json:"order_id"`
+
+	// ExpectedTaker and ExpectedMaker sum the per-trade rounded fees.
+	ExpectedTaker int `json:"expected_taker"`
+	ExpectedMaker int `json:"expected_maker"`
+	// ExpectedAggregate rounds once per role over the combined fills, the
+	// result when several fills are charged as a single trade.
+	ExpectedAggregate int `json:"expected_aggregate"`
+
+	ReportedTaker int  `json:"reported_taker"`
+	ReportedMaker int  `json:"reported_maker"`
+	HasSplit      bool `json:"has_split"` // Reported{Taker,Maker} are known separately
+
+	// Diff is reported total minus expected total (per-trade rounding).
+	Diff int `json:"diff"`
+}
+
+// ExpectedTotal returns the expected fee total using per-trade rounding.
+func (r FeeReconciliation) ExpectedTotal() int {
+	return r.ExpectedTaker + r.ExpectedMaker
+}
+
+// ReportedTotal returns the reported fee total.
+func (r FeeReconciliation) ReportedTotal() int {
+	return r.ReportedTaker + r.ReportedMaker
+}
+
+// Matches returns whether the reported fees equal the per-trade expectation.
+func (r FeeReconciliation) Matches() bool {
+	return r.Diff == 0
+}
+
+// ExplainedByRounding returns whether a mismatch disappears when the fills
+// are rounded as a single trade per role instead of per trade.
+func (r FeeReconciliation) ExplainedByRounding() bool {
+	return r.Diff != 0 && r.ReportedTotal() == r.ExpectedAggregate
+}
+
+// expectFromFills fills in the expected fees from the fills belonging to orderID.
+// It returns false if none of the fills belong to the order.
+func (s FeeSchedule) expectFromFills(r *FeeReconciliation, orderID string, fills []Fill) bool {
+	var found bool
+	var takerNotional, makerNotional decimal.Decimal
+	for i := range fills {
+		f := &fills[i]
+		if f.OrderID != orderID {
+			continue
+		}
+		found = true
+		price := sidePrice(f.Side, f.YesPrice, f.NoPrice)
+		notional := decimal.NewFromInt(int64(f.Count) * int64(price) * int64(100-price))
+		if f.IsTaker {
+			r.ExpectedTaker += s.TakerFee(price, f.Count)
+			takerNotional = takerNotional.Add(notional)
+		} else {
+			r.ExpectedMaker += s.MakerFee(price, f.Count)
+			makerNotional = makerNotional.Add(notional)
+		}
+	}
+	r.ExpectedAggregate = int(s.TakerRate.Mul(takerNotional).Div(hundred).Ceil().IntPart()) +
+		int(s.MakerRate.Mul(makerNotional).Div(hundred).Ceil().IntPart())
+	return found
+}
+
+// ReconcileOrder compares o.TakerFees / o.MakerFees with the fees expected
+// from fills (those with a matching OrderID). Without matching fills the
+// order's TakerFillCount and MakerFillCount are assumed to have executed at
+// its limit price.
+func (s FeeSchedule) ReconcileOrder(o *Order, fills []Fill) FeeReconciliation {
+	r := FeeReconciliation{
+		OrderID:       o.OrderID,
+		ReportedTaker: o.TakerFees,
+		ReportedMaker: o.MakerFees,
+		HasSplit:      true,
+	}
+	if !s.expectFromFills(&r, o.OrderID, fills) {
+		price := sidePrice(o.Side, o.YesPrice, o.NoPrice)
+		r.ExpectedTaker = s.TakerFee(price, o.TakerFillCount)
+		r.ExpectedMaker = s.MakerFee(price, o.MakerFillCount)
+		r.ExpectedAggregate = r.ExpectedTaker + r.ExpectedMaker
+	}
+	r.Diff = r.ReportedTotal() - r.ExpectedTotal()
+	return r
+}
+
+// ReconcileOrderResponse compares o.FeesPaid with the fees expected from
+// fills (those with a matching OrderID). The internal API does not split
+// taker and maker fees, so FeesPaid is reported as taker. Without matching
+// fills the whole FilledCount is assumed to have executed as taker at the
+// order's price.
+func (s FeeSchedule) ReconcileOrderResponse(o *OrderResponse, fills []Fill) FeeReconciliation {
+	r := FeeReconciliation{
+		OrderID:       o.OrderID,
+		ReportedTaker: o.FeesPaid,
+	}
+	if !s.expectFromFills(&r, o.OrderID, fills) {
+		price := sidePrice(Side(o.Side), o.YesPrice, o.NoPrice)
+		r.ExpectedTaker = s.TakerFee(price, o.FilledCount)
+		r.ExpectedAggregate = r.ExpectedTaker
+	}
+	r.Diff = r.ReportedTotal() - r.ExpectedTotal()
+	return r
+}
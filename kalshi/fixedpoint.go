@@ -0,0 +1,177 @@
+package kalshi
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// Kalshi reports counts both as integers and as fixed-point strings (*_fp),
+// and costs both in cents and as dollar strings (*_dollars). The string forms
+// carry fractional contracts and subpenny amounts; the integers truncate them.
+// The *Decimal accessors below prefer the string forms and fall back to the
+// integers when a string is empty or malformed.
+
+// fpOr parses s, falling back to n when s is empty or malformed.
+func fpOr(s string, n int) decimal.Decimal {
+	if s != "" {
+		if d, err := decimal.NewFromString(s); err == nil {
+			return d
+		}
+	}
+	return decimal.NewFromInt(int64(n))
+}
+
+// dollarsOr parses s as dollars, falling back to cents when s is empty or malformed.
+func dollarsOr(s string, cents int) decimal.Decimal {
+	if s != "" {
+		if d, err := decimal.NewFromString(s); err == nil {
+			return d
+		}
+	}
+	return CentsToDollars(int64(cents))
+}
+
+// InitialCountDecimal returns the initial contract count, preferring InitialCountFP.
+func (o *Order) InitialCountDecimal() decimal.Decimal {
+	return fpOr(o.InitialCountFP, o.InitialCount)
+}
+
+// RemainingCountDecimal returns the remaining contract count, preferring RemainingCountFP.
+func (o *Order) RemainingCountDecimal() decimal.Decimal {
+	return fpOr(o.RemainingCountFP, o.RemainingCount)
+}
+
+// FillCountDecimal returns the filled contract count, preferring FillCountFP.
+func (o *Order) FillCountDecimal() decimal.Decimal {
+	return fpOr(o.FillCountFP, o.FillCount)
+}
+
+// YesPriceDecimal returns the YES price in dollars, preferring YesPriceDollars.
+func (o *Order) YesPriceDecimal() decimal.Decimal {
+	return dollarsOr(o.YesPriceDollars, o.YesPrice)
+}
+
+// NoPriceDecimal returns the NO price in dollars, preferring NoPriceDollars.
+func (o *Order) NoPriceDecimal() decimal.Decimal {
+	return dollarsOr(o.NoPriceDollars, o.NoPrice)
+}
+
+// PriceDecimal returns the price in dollars on the order's side.
+func (o *Order) PriceDecimal() decimal.Decimal {
+	if o.Side == SideNo {
+		return o.NoPriceDecimal()
+	}
+	return o.YesPriceDecimal()
+}
+
+// TakerFillCostDecimal returns the taker fill cost in dollars, preferring TakerFillCostDollars.
+func (o *Order) TakerFillCostDecimal() decimal.Decimal {
+	return dollarsOr(o.TakerFillCostDollars, o.TakerFillCost)
+}
+
+// MakerFillCostDecimal returns the maker fill cost in dollars, preferring MakerFillCostDollars.
+func (o *Order) MakerFillCostDecimal() decimal.Decimal {
+	return dollarsOr(o.MakerFillCostDollars, o.MakerFillCost)
+}
+
+// TakerFeesDecimal returns the taker fees in dollars, preferring TakerFeesDollars.
+func (o *Order) TakerFeesDecimal() decimal.Decimal {
+	return dollarsOr(o.TakerFeesDollars, o.TakerFees)
+}
+
+// MakerFeesDecimal returns the maker fees in dollars, preferring MakerFeesDollars.
+func (o *Order) MakerFeesDecimal() decimal.Decimal {
+	return dollarsOr(o.MakerFeesDollars, o.MakerFees)
+}
+
+// TotalFillCountDecimal returns the total number of filled contracts,
+// including fractional contracts. It uses FillCountFP when set, otherwise
+// TakerFillCount + MakerFillCount.
+func (o *Order) TotalFillCountDecimal() decimal.Decimal {
+	return fpOr(o.FillCountFP, o.TotalFillCount())
+}
+
+// TotalFillCostDecimal returns the total cost of filled contracts in dollars,
+// including subpenny amounts.
+func (o *Order) TotalFillCostDecimal() decimal.Decimal {
+	return o.TakerFillCostDecimal().Add(o.MakerFillCostDecimal())
+}
+
+// TotalFeesDecimal returns the total fees in dollars, including subpenny amounts.
+func (o *Order) TotalFeesDecimal() decimal.Decimal {
+	return o.TakerFeesDecimal().Add(o.MakerFeesDecimal())
+}
+
+// FieldMismatch reports a disagreement between an integer field and its
+// fixed-point or dollars twin.
+type FieldMismatch struct {
+	Field  string `json:"field"` // JSON name of the string field
+	Int    int    `json:"int"`   // integer value (count or cents)
+	Value  string `json:"value"` // string value as received
+	Reason string `json:"reason"`
+}
+
+// String implements fmt.Stringer.
+func (m FieldMismatch) String() string {
+	return fmt.Sprintf("%s=%q vs %d: %s", m.Field, m.Value, m.Int, m.Reason)
+}
+
+// CheckFixedPoint compares every integer field of o with its *_fp or
+// *_dollars twin and returns the disagreements. A count disagrees when the
+// truncated fixed-point value differs from the integer; an amount disagrees
+// when dollars and cents differ by a cent or more. Malformed strings are
+// reported, empty strings are skipped. It also checks that the fixed-point
+// fill and remaining counts add up to the initial count.
+func (o *Order) CheckFixedPoint() []FieldMismatch {
+	var out []FieldMismatch
+	checkCount := func(field, s string, n int) {
+		if s == "" {
+			return
+		}
+		d, err := decimal.NewFromString(s)
+		if err != nil {
+			out = append(out, FieldMismatch{Field: field, Int: n, Value: s, Reason: "malformed"})
+			return
+		}
+		if !d.Truncate(0).Equal(decimal.NewFromInt(int64(n))) {
+			out = append(out, FieldMismatch{Field: field, Int: n, Value: s, Reason: "count differs"})
+		}
+	}
+	checkDollars := func(field, s string, cents int) {
+		if s == "" {
+			return
+		}
+		d, err := decimal.NewFromString(s)
+		if err != nil {
+			out = append(out, FieldMismatch{Field: field, Int: cents, Value: s, Reason: "malformed"})
+			return
+		}
+		if d.Mul(hundred).Sub(decimal.NewFromInt(int64(cents))).Abs().GreaterThanOrEqual(decimal.NewFromInt(1)) {
+			out = append(out, FieldMismatch{Field: field, Int: cents, Value: s, Reason: "amount differs"})
+		}
+	}
+
+	checkCount("initial_count_fp", o.InitialCountFP, o.InitialCount)
+	checkCount("remaining_count_fp", o.RemainingCountFP, o.RemainingCount)
+	checkCount("fill_count_fp", o.FillCountFP, o.FillCount)
+	checkDollars("yes_price_dollars", o.YesPriceDollars, o.YesPrice)
+	checkDollars("no_price_dollars", o.NoPriceDollars, o.NoPrice)
+	checkDollars("taker_fill_cost_dollars", o.TakerFillCostDollars, o.TakerFillCost)
+	checkDollars("maker_fill_cost_dollars", o.MakerFillCostDollars, o.MakerFillCost)
+	checkDollars("taker_fees_dollars", o.TakerFeesDollars, o.TakerFees)
+	checkDollars("maker_fees_dollars", o.MakerFeesDollars, o.MakerFees)
+
+	if o.InitialCountFP != "" && o.FillCountFP != "" && o.RemainingCountFP != "" {
+		sum := o.FillCountDecimal().Add(o.RemainingCountDecimal())
+		if !sum.Equal(o.InitialCountDecimal()) {
+			out = append(out, FieldMismatch{
+				Field:  "initial_count_fp",
+				Int:    o.InitialCount,
+				Value:  o.InitialCountFP,
+				Reason: "fill_count_fp + remaining_count_fp = " + sum.String(),
+			})
+		}
+	}
+	return out
+}
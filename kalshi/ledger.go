@@ -0,0 +1,164 @@
+package kalshi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLedgerDivergence is wrapped by the errors of LedgerReport.
+var ErrLedgerDivergence = errors.New("kalshi: ledger divergence")
+
+// FlowDirection returns the effect of a flow type on the available balance:
+// +1 for Recharge, Sell, Settle and Unfreeze, -1 for Withdraw, Buy, Fee and
+// Freeze, and 0 for unknown types. Freeze and Unfreeze move the amount
+// between the available balance and the frozen balance.
+func FlowDirection(flowType int) int {
+	switch flowType {
+	case FlowTypeRecharge, FlowTypeSell, FlowTypeSettle, FlowTypeUnfreeze:
+		return 1
+	case FlowTypeWithdraw, FlowTypeBuy, FlowTypeFee, FlowTypeFreeze:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// SignedAmount returns the flow amount signed by FlowDirection. Amount may be
+// recorded either as a magnitude or already signed; both give the same result.
+func (f *FlowResponse) SignedAmount() int64 {
+	amount := f.Amount
+	if amount < 0 {
+		amount = -amount
+	}
+	return int64(FlowDirection(f.FlowType)) * amount
+}
+
+// LedgerDivergence describes a flow that breaks the ledger.
+type LedgerDivergence struct {
+	Index    int          `json:"index"` // position in the replayed slice
+	Flow     FlowResponse `json:"flow"`
+	Reason   string       `json:"reason"`
+	Expected int64        `json:"expected"`
+	Actual   int64        `json:"actual"`
+}
+
+// Error implements the error interface; it wraps ErrLedgerDivergence.
+func (d *LedgerDivergence) Error() string {
+	return fmt.Sprintf("%v: flow %d (#%d, type %d): %s: expected %d, got %d",
+		ErrLedgerDivergence, d.Flow.ID, d.Index, d.Flow.FlowType, d.Reason, d.Expected, d.Actual)
+}
+
+// Unwrap returns ErrLedgerDivergence.
+func (d *LedgerDivergence) Unwrap() error {
+	return ErrLedgerDivergence
+}
+
+// LedgerReport is the result of replaying a sequence of fund flows.
+type LedgerReport struct {
+	// Account holds the rebuilt Balance, Frozen and TotalPnl. TotalPnl is
+	// cash-based: Sell + Settle - Buy - Fee.
+	Account     AccountResponse    `json:"account"`
+	Records     int                `json:"records"`
+	Divergences []LedgerDivergence `json:"divergences"`
+}
+
+// FirstDivergence returns the earliest divergent record, or nil if the ledger is consistent.
+func (r *LedgerReport) FirstDivergence() *LedgerDivergence {
+	if len(r.Divergences) == 0 {
+		return nil
+	}
+	return &r.Divergences[0]
+}
+
+// Err returns the first divergence as an error, or nil.
+func (r *LedgerReport) Err() error {
+	if d := r.FirstDivergence(); d != nil {
+		return d
+	}
+	return nil
+}
+
+func (r *LedgerReport) diverge(i int, f *FlowResponse, reason string, expected, actual int64) {
+	r.Divergences = append(r.Divergences, LedgerDivergence{
+		Index: i, Flow: *f, Reason: reason, Expected: expected, Actual: actual,
+	})
+}
+
+// ReplayFlows replays flows in the given (chronological) order starting from
+// opening, or from the first flow's BalanceBefore when opening is nil. For
+// every flow it checks:
+//   - the flow type is known
+//   - a signed Amount agrees with the flow type's direction
+//   - BalanceAfter = BalanceBefore + SignedAmount
+//   - BalanceBefore equals the previous flow's BalanceAfter (or the opening balance)
+//   - unfreezing never takes Frozen below zero, when opening is set
+//
+// Without opening the frozen balance before the first flow is unknown, so
+// Frozen in the result is relative to it and may be negative.
+//
+// The rebuilt balance follows the amounts, so a broken record shows up in the
+// divergences rather than being copied into the result.
+func ReplayFlows(opening *AccountResponse, flows []FlowResponse) *LedgerReport {
+	r := &LedgerReport{Records: len(flows)}
+	if opening != nil {
+		r.Account = *opening
+	} else if len(flows) > 0 {
+		r.Account.UserID = flows[0].UserID
+		r.Account.Balance = flows[0].BalanceBefore
+	}
+	prevAfter := r.Account.Balance
+
+	for i := range flows {
+		f := &flows[i]
+		dir := FlowDirection(f.FlowType)
+		if dir == 0 {
+			r.diverge(i, f, "unknown flow type", 0, int64(f.FlowType))
+			prevAfter = f.BalanceAfter
+			continue
+		}
+		if f.Amount < 0 && dir > 0 {
+			r.diverge(i, f, "negative amount for crediting flow", -f.Amount, f.Amount)
+		}
+		if f.BalanceBefore != prevAfter {
+			r.diverge(i, f, "balance_before does not continue the ledger", prevAfter, f.BalanceBefore)
+		}
+		prevAfter = f.BalanceAfter
+
+		delta := f.SignedAmount()
+		if f.BalanceAfter != f.BalanceBefore+delta {
+			r.diverge(i, f, "balance_after != balance_before + amount", f.BalanceBefore+delta, f.BalanceAfter)
+		}
+
+		r.Account.Balance += delta
+		switch f.FlowType {
+		case FlowTypeFreeze:
+			r.Account.Frozen -= delta
+		case FlowTypeUnfreeze:
+			r.Account.Frozen -= delta
+			if opening != nil && r.Account.Frozen < 0 {
+				r.diverge(i, f, "unfreeze exceeds frozen balance", 0, r.Account.Frozen)
+			}
+		case FlowTypeBuy, FlowTypeSell, FlowTypeSettle, FlowTypeFee:
+			r.Account.TotalPnl += delta
+		}
+	}
+	return r
+}
+
+// CompareAccount checks the rebuilt account against a reported one and returns
+// a divergence error (indexed after the last flow) for the first field that differs.
+func (r *LedgerReport) CompareAccount(reported *AccountResponse) error {
+	check := func(field string, expected, actual int64) error {
+		if expected == actual {
+			return nil
+		}
+		return &LedgerDivergence{Index: r.Records, Reason: field + " differs from replay", Expected: expected, Actual: actual}
+	}
+	if err := check("balance", r.Account.Balance, reported.Balance); err != nil {
+		return err
+	}
+	if err := check("frozen", r.Account.Frozen, reported.Frozen); err != nil {
+		return err
+	}
+	return check("total_pnl", r.Account.TotalPnl, reported.TotalPnl)
+}
@@ -0,0 +1,66 @@
+package kalshi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidMarketTransition is returned by ValidateMarketTransition for a move
+// the Kalshi market lifecycle does not allow.
+var ErrInvalidMarketTransition = errors.New("kalshi: invalid market status transition")
+
+// Market lifecycle:
+//
+//	initialized -> active <-> inactive
+//	active / inactive -> closed -> determined -> finalized
+//	active / inactive -> determined
+//	determined -> disputed -> amended -> finalized
+//
+// The lifecycle WebSocket channel has no close event, so a market may move
+// from active or inactive straight to determined. A determined or amended
+// result may be disputed (again) before finalization.
+var marketStatusTransitions = map[MarketStatus][]MarketStatus{
+	MarketStatusInitialized: {MarketStatusActive, MarketStatusInactive},
+	MarketStatusActive:      {MarketStatusInactive, MarketStatusClosed, MarketStatusDetermined},
+	MarketStatusInactive:    {MarketStatusActive, MarketStatusClosed, MarketStatusDetermined},
+	MarketStatusClosed:      {MarketStatusDetermined},
+	MarketStatusDetermined:  {MarketStatusDisputed, MarketStatusAmended, MarketStatusFinalized},
+	MarketStatusDisputed:    {MarketStatusAmended, MarketStatusDetermined, MarketStatusFinalized},
+	MarketStatusAmended:     {MarketStatusDisputed, MarketStatusFinalized},
+}
+
+// IsValid returns whether s is a known Kalshi market status.
+func (s MarketStatus) IsValid() bool {
+	switch s {
+	case MarketStatusInitialized, MarketStatusInactive, MarketStatusActive, MarketStatusClosed,
+		MarketStatusDetermined, MarketStatusDisputed, MarketStatusAmended, MarketStatusFinalized:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo returns whether the market may move from s to next.
+// Staying in the same status is always allowed.
+func (s MarketStatus) CanTransitionTo(next MarketStatus) bool {
+	if !s.IsValid() || !next.IsValid() {
+		return false
+	}
+	if s == next {
+		return true
+	}
+	for _, allowed := range marketStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateMarketTransition validates the move from -> to.
+// Errors wrap ErrInvalidMarketTransition.
+func ValidateMarketTransition(from, to MarketStatus) error {
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %q -> %q", ErrInvalidMarketTransition, from, to)
+	}
+	return nil
+}
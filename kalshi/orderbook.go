@@ -0,0 +1,232 @@
+package kalshi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+// Kalshi orderbooks only carry bids. A YES bid at P is equivalent to a NO ask
+// at 100 - P (and vice versa), so the ask ladder of one side is implied by the
+// bid ladder of the other.
+
+var oneDollar = decimal.NewFromInt(1)
+
+// DollarLevel is a price level with the price in dollars. Count is a decimal
+// because the dollars arrays may carry fractional contract counts.
+type DollarLevel struct {
+	Price decimal.Decimal `json:"price"`
+	Count decimal.Decimal `json:"count"`
+}
+
+// Dollars converts a cents level to a DollarLevel.
+func (l OrderbookLevel) Dollars() DollarLevel {
+	return DollarLevel{Price: CentsToDollars(int64(l.Price)), Count: decimal.NewFromInt(int64(l.Count))}
+}
+
+// ToDollarLevels converts cents levels to DollarLevels, keeping their order.
+func ToDollarLevels(levels []OrderbookLevel) []DollarLevel {
+	out := make([]DollarLevel, len(levels))
+	for i, l := range levels {
+		out[i] = l.Dollars()
+	}
+	return out
+}
+
+// GetYesDollarLevels parses the YesDollars orderbook into DollarLevel slice.
+// Malformed entries are skipped.
+func (o *Orderbook) GetYesDollarLevels() []DollarLevel {
+	return parseDollarLevels(o.YesDollars)
+}
+
+// GetNoDollarLevels parses the NoDollars orderbook into DollarLevel slice.
+// Malformed entries are skipped.
+func (o *Orderbook) GetNoDollarLevels() []DollarLevel {
+	return parseDollarLevels(o.NoDollars)
+}
+
+func parseDollarLevels(pairs [][]any) []DollarLevel {
+	levels := make([]DollarLevel, 0, len(pairs))
+	for _, pair := range pairs {
+		if len(pair) < 2 {
+			continue
+		}
+		price, err := anyDecimal(pair[0])
+		if err != nil {
+			continue
+		}
+		count, err := anyDecimal(pair[1])
+		if err != nil {
+			continue
+		}
+		levels = append(levels, DollarLevel{Price: price, Count: count})
+	}
+	return levels
+}
+
+// anyDecimal converts a JSON-decoded number or numeric string to a decimal.
+func anyDecimal(v any) (decimal.Decimal, error) {
+	switch x := v.(type) {
+	case string:
+		return decimal.NewFromString(x)
+	case float64:
+		return decimal.NewFromFloat(x), nil
+	case int:
+		return decimal.NewFromInt(int64(x)), nil
+	case int64:
+		return decimal.NewFromInt(x), nil
+	case json.Number:
+		return decimal.NewFromString(x.String())
+	default:
+		return decimal.Zero, fmt.Errorf("kalshi: unexpected orderbook value %T", v)
+	}
+}
+
+// ImpliedYesAsks returns the YES ask ladder implied by the NO bids
+// (price 100 - NO bid), best (lowest) price first.
+func (o *Orderbook) ImpliedYesAsks() []OrderbookLevel {
+	return impliedAsks(o.GetNoLevels())
+}
+
+// ImpliedNoAsks returns the NO ask ladder implied by the YES bids
+// (price 100 - YES bid), best (lowest) price first.
+func (o *Orderbook) ImpliedNoAsks() []OrderbookLevel {
+	return impliedAsks(o.GetYesLevels())
+}
+
+func impliedAsks(bids []OrderbookLevel) []OrderbookLevel {
+	asks := make([]OrderbookLevel, 0, len(bids))
+	for _, b := range bids {
+		if b.Count > 0 {
+			asks = append(asks, OrderbookLevel{Price: 100 - b.Price, Count: b.Count})
+		}
+	}
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price < asks[j].Price })
+	return asks
+}
+
+// bidsDollars returns the bids of side in dollars, preferring the subpenny
+// dollars arrays and falling back to the cents arrays.
+func (o *Orderbook) bidsDollars(side Side) []DollarLevel {
+	var levels []DollarLevel
+	if side == SideNo {
+		if len(o.NoDollars) > 0 {
+			levels = o.GetNoDollarLevels()
+		} else {
+			levels = ToDollarLevels(o.GetNoLevels())
+		}
+	} else {
+		if len(o.YesDollars) > 0 {
+			levels = o.GetYesDollarLevels()
+		} else {
+			levels = ToDollarLevels(o.GetYesLevels())
+		}
+	}
+	bids := make([]DollarLevel, 0, len(levels))
+	for _, l := range levels {
+		if l.Count.IsPositive() {
+			bids = append(bids, l)
+		}
+	}
+	return bids
+}
+
+// Bids returns the bid ladder of side in dollars, best (highest) price first.
+// The YesDollars/NoDollars arrays are used when present, otherwise the cents arrays.
+func (o *Orderbook) Bids(side Side) []DollarLevel {
+	bids := o.bidsDollars(side)
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price.GreaterThan(bids[j].Price) })
+	return bids
+}
+
+// Asks returns the ask ladder of side in dollars implied by the opposite
+// side's bids (price 1 - bid), best (lowest) price first.
+func (o *Orderbook) Asks(side Side) []DollarLevel {
+	opposite := SideNo
+	if side == SideNo {
+		opposite = SideYes
+	}
+	bids := o.bidsDollars(opposite)
+	asks := make([]DollarLevel, len(bids))
+	for i, b := range bids {
+		asks[i] = DollarLevel{Price: oneDollar.Sub(b.Price), Count: b.Count}
+	}
+	sort.Slice(asks, func(i, j int) bool { return asks[i].Price.LessThan(asks[j].Price) })
+	return asks
+}
+
+// BookQuote is the top of book of one side, in dollars.
+type BookQuote struct {
+	Side    Side            `json:"side"`
+	BestBid decimal.Decimal `json:"best_bid"`
+	BidSize decimal.Decimal `json:"bid_size"`
+	BestAsk decimal.Decimal `json:"best_ask"`
+	AskSize decimal.Decimal `json:"ask_size"`
+	HasBid  bool            `json:"has_bid"`
+	HasAsk  bool            `json:"has_ask"`
+}
+
+// Quote returns the top of book of side, with the ask implied by the opposite side's bids.
+func (o *Orderbook) Quote(side Side) BookQuote {
+	q := BookQuote{Side: side}
+	if bids := o.Bids(side); len(bids) > 0 {
+		q.BestBid, q.BidSize, q.HasBid = bids[0].Price, bids[0].Count, true
+	}
+	if asks := o.Asks(side); len(asks) > 0 {
+		q.BestAsk, q.AskSize, q.HasAsk = asks[0].Price, asks[0].Count, true
+	}
+	return q
+}
+
+// Spread returns BestAsk - BestBid, or false when either side is empty.
+func (q BookQuote) Spread() (decimal.Decimal, bool) {
+	if !q.HasBid || !q.HasAsk {
+		return decimal.Zero, false
+	}
+	return q.BestAsk.Sub(q.BestBid), true
+}
+
+// Mid returns (BestBid + BestAsk) / 2, or false when either side is empty.
+func (q BookQuote) Mid() (decimal.Decimal, bool) {
+	if !q.HasBid || !q.HasAsk {
+		return decimal.Zero, false
+	}
+	return q.BestBid.Add(q.BestAsk).Div(decimal.NewFromInt(2)), true
+}
+
+// Microprice returns the size-weighted mid
+// (BestBid * AskSize + BestAsk * BidSize) / (BidSize + AskSize),
+// or false when either side is empty.
+func (q BookQuote) Microprice() (decimal.Decimal, bool) {
+	if !q.HasBid || !q.HasAsk {
+		return decimal.Zero, false
+	}
+	total := q.BidSize.Add(q.AskSize)
+	if !total.IsPositive() {
+		return q.Mid()
+	}
+	return q.BestBid.Mul(q.AskSize).Add(q.BestAsk.Mul(q.BidSize)).Div(total), true
+}
+
+// DepthLevel is a price level with the totals of all levels up to and including it.
+type DepthLevel struct {
+	Price       decimal.Decimal `json:"price"`
+	Count       decimal.Decimal `json:"count"`
+	CumCount    decimal.Decimal `json:"cum_count"`
+	CumNotional decimal.Decimal `json:"cum_notional"` // sum of price * count, in dollars
+}
+
+// CumulativeDepth accumulates levels in the given order, which should be best
+// price first (as returned by Bids and Asks).
+func CumulativeDepth(levels []DollarLevel) []DepthLevel {
+	out := make([]DepthLevel, len(levels))
+	var count, notional decimal.Decimal
+	for i, l := range levels {
+		count = count.Add(l.Count)
+		notional = notional.Add(l.Price.Mul(l.Count))
+		out[i] = DepthLevel{Price: l.Price, Count: l.Count, CumCount: count, CumNotional: notional}
+	}
+	return out
+}
@@ -0,0 +1,373 @@
+package kalshi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/predictpaul/common"
+	"github.com/predictpaul/common/service"
+)
+
+// MaxBatchOrders is the largest number of orders in a batch create or cancel.
+const MaxBatchOrders = 20
+
+// ErrBatchItemFailed is wrapped by the per-item errors of a batch response.
+var ErrBatchItemFailed = errors.New("kalshi: batch item failed")
+
+// AmendOrderParams changes the price and/or count of a resting order in place.
+// Count is the new total count of the order (filled plus remaining). Ticker,
+// Side and Action must match the order.
+type AmendOrderParams struct {
+	Ticker               string `json:"ticker"`
+	Side                 Side   `json:"side"`
+	Action               Action `json:"action"`
+	ClientOrderID        string `json:"client_order_id,omitempty"`
+	UpdatedClientOrderID string `json:"updated_client_order_id,omitempty"`
+	Count                int    `json:"count"`
+	YesPrice             int    `json:"yes_price,omitempty"`
+	NoPrice              int    `json:"no_price,omitempty"`
+}
+
+// Validate checks p against the Kalshi amend rules and returns a
+// common.ValidationErrors, or nil. m is optional and checked as in
+// CreateOrderParams.Validate.
+func (p *AmendOrderParams) Validate(m *Market) error {
+	var errs common.ValidationErrors
+	validateOrderTarget(&errs, p.Ticker, p.Side, p.Action, m)
+	validatePrices(&errs, p.YesPrice, p.NoPrice, true, m)
+	if p.Count <= 0 {
+		errs.Add("count", "must be positive")
+	}
+	if p.ClientOrderID != "" && !IsValidClientOrderID(p.ClientOrderID) {
+		errs.Add("client_order_id", fmt.Sprintf("must be 1-%d letters, digits, '-' or '_'", MaxClientOrderIDLength))
+	}
+	if p.UpdatedClientOrderID != "" && !IsValidClientOrderID(p.UpdatedClientOrderID) {
+		errs.Add("updated_client_order_id", fmt.Sprintf("must be 1-%d letters, digits, '-' or '_'", MaxClientOrderIDLength))
+	}
+	return errs.Err()
+}
+
+// AmendOrderResponse is the response of POST /portfolio/orders/{order_id}/amend.
+type AmendOrderResponse struct {
+	OldOrder Order `json:"old_order"`
+	Order    Order `json:"order"`
+}
+
+// DecreaseOrderParams reduces the remaining count of a resting order, either
+// by ReduceBy contracts or down to ReduceTo contracts. ReduceTo 0 cancels the order.
+type DecreaseOrderParams struct {
+	ReduceBy int  `json:"reduce_by,omitempty"`
+	ReduceTo *int `json:"reduce_to,omitempty"`
+}
+
+// Validate checks that exactly one of ReduceBy and ReduceTo is set and in range.
+func (p *DecreaseOrderParams) Validate() error {
+	var errs common.ValidationErrors
+	switch {
+	case p.ReduceBy != 0 && p.ReduceTo != nil:
+		errs.Add("reduce_to", "only one of reduce_by and reduce_to may be set")
+	case p.ReduceBy == 0 && p.ReduceTo == nil:
+		errs.Add("reduce_by", "reduce_by or reduce_to is required")
+	}
+	if p.ReduceBy < 0 {
+		errs.Add("reduce_by", "must be positive")
+	}
+	if p.ReduceTo != nil && *p.ReduceTo < 0 {
+		errs.Add("reduce_to", "must not be negative")
+	}
+	return errs.Err()
+}
+
+// DecreaseOrderResponse is the response of POST /portfolio/orders/{order_id}/decrease.
+type DecreaseOrderResponse struct {
+	Order Order `json:"order"`
+}
+
+// BatchCreateOrdersRequest is the body of POST /portfolio/orders/batched.
+type BatchCreateOrdersRequest struct {
+	Orders []CreateOrderParams `json:"orders"`
+}
+
+// batchField returns the field name of item i of a batch, e.g. "orders[3].count".
+func batchField(list string, i int, field string) string {
+	return fmt.Sprintf("%s[%d].%s", list, i, field)
+}
+
+// Validate checks that the batch holds 1 to MaxBatchOrders orders with
+// distinct client order IDs and validates each order. markets is optional and
+// looked up by ticker. Field names are prefixed with "orders[i].".
+func (r *BatchCreateOrdersRequest) Validate(markets map[string]*Market, now time.Time) error {
+	var errs common.ValidationErrors
+	switch {
+	case len(r.Orders) == 0:
+		errs.Add("orders", "must contain at least one order")
+	case len(r.Orders) > MaxBatchOrders:
+		errs.Add("orders", fmt.Sprintf("must contain at most %d orders", MaxBatchOrders))
+	}
+	ids := make(map[string]int, len(r.Orders))
+	for i := range r.Orders {
+		p := &r.Orders[i]
+		if err := p.Validate(markets[p.Ticker], now); err != nil {
+			var itemErrs common.ValidationErrors
+			if errors.As(err, &itemErrs) {
+				for _, fe := range itemErrs {
+					errs.Add(batchField("orders", i, fe.Field), fe.Message)
+				}
+			}
+		}
+		if p.ClientOrderID == "" {
+			continue
+		}
+		if j, ok := ids[p.ClientOrderID]; ok {
+			errs.Add(batchField("orders", i, "client_order_id"), fmt.Sprintf("duplicates orders[%d]", j))
+		} else {
+			ids[p.ClientOrderID] = i
+		}
+	}
+	return errs.Err()
+}
+
+// BatchCancelOrdersRequest is the body of DELETE /portfolio/orders/batched.
+type BatchCancelOrdersRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// Validate checks that the batch holds 1 to MaxBatchOrders distinct, non-empty order IDs.
+func (r *BatchCancelOrdersRequest) Validate() error {
+	var errs common.ValidationErrors
+	switch {
+	case len(r.IDs) == 0:
+		errs.Add("ids", "must contain at least one order id")
+	case len(r.IDs) > MaxBatchOrders:
+		errs.Add("ids", fmt.Sprintf("must contain at most %d order ids", MaxBatchOrders))
+	}
+	seen := make(map[string]int, len(r.IDs))
+	for i, id := range r.IDs {
+		field := fmt.Sprintf("ids[%d]", i)
+		if id == "" {
+			errs.Add(field, "is required")
+			continue
+		}
+		if j, ok := seen[id]; ok {
+			errs.Add(field, fmt.Sprintf("duplicates ids[%d]", j))
+		} else {
+			seen[id] = i
+		}
+	}
+	return errs.Err()
+}
+
+// BatchOrderResult is one item of a batch response: the order, or the error
+// that rejected it.
+type BatchOrderResult struct {
+	OrderID       string    `json:"order_id,omitempty"`        // batch cancel
+	ClientOrderID string    `json:"client_order_id,omitempty"` // batch create
+	Order         *Order    `json:"order"`
+	ReducedBy     int       `json:"reduced_by,omitempty"` // batch cancel
+	Error         *APIError `json:"error"`
+}
+
+// OrderResult is a batch item mapped to the request it answers.
+type OrderResult struct {
+	Index         int    `json:"index"` // position in the request
+	OrderID       string `json:"order_id"`
+	ClientOrderID string `json:"client_order_id"`
+	Order         *Order `json:"order"`
+	Err           error  `json:"-"` // wraps ErrBatchItemFailed and the item's *APIError
+}
+
+// OK reports whether the item succeeded.
+func (r *OrderResult) OK() bool {
+	return r.Err == nil
+}
+
+// mapBatchResults turns batch items into OrderResults. Kalshi returns the
+// items in request order.
+func mapBatchResults(list string, items []BatchOrderResult) []OrderResult {
+	out := make([]OrderResult, len(items))
+	for i, item := range items {
+		res := OrderResult{Index: i, OrderID: item.OrderID, ClientOrderID: item.ClientOrderID, Order: item.Order}
+		if item.Order != nil {
+			if res.OrderID == "" {
+				res.OrderID = item.Order.OrderID
+			}
+			if res.ClientOrderID == "" {
+				res.ClientOrderID = item.Order.ClientOrderID
+			}
+		}
+		switch {
+		case item.Error != nil:
+			res.Err = fmt.Errorf("%w: %s[%d]: %w", ErrBatchItemFailed, list, i, item.Error)
+		case item.Order == nil:
+			res.Err = fmt.Errorf("%w: %s[%d]: no order returned", ErrBatchItemFailed, list, i)
+		}
+		out[i] = res
+	}
+	return out
+}
+
+// joinResultErrors returns the errors of the failed results joined, or nil.
+func joinResultErrors(results []OrderResult) error {
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BatchCreateOrdersResponse is the response of POST /portfolio/orders/batched.
+type BatchCreateOrdersResponse struct {
+	Orders []BatchOrderResult `json:"orders"`
+}
+
+// Results maps each item to the order it creates, in request order.
+func (r *BatchCreateOrdersResponse) Results() []OrderResult {
+	return mapBatchResults("orders", r.Orders)
+}
+
+// Err returns the errors of the rejected orders joined, or nil.
+func (r *BatchCreateOrdersResponse) Err() error {
+	return joinResultErrors(r.Results())
+}
+
+// BatchCancelOrdersResponse is the response of DELETE /portfolio/orders/batched.
+type BatchCancelOrdersResponse struct {
+	Orders []BatchOrderResult `json:"orders"`
+}
+
+// Results maps each item to the order it cancels, in request order.
+func (r *BatchCancelOrdersResponse) Results() []OrderResult {
+	return mapBatchResults("ids", r.Orders)
+}
+
+// Err returns the errors of the orders that could not be canceled joined, or nil.
+func (r *BatchCancelOrdersResponse) Err() error {
+	return joinResultErrors(r.Results())
+}
+
+// CancelResult returns the canceled and rejected order IDs. ids is the
+// request, used for items whose response carries no order ID.
+func (r *BatchCancelOrdersResponse) CancelResult(ids []string) service.CancelResult {
+	res := service.CancelResult{AcceptedIDs: []string{}, RejectedIDs: []string{}}
+	for _, item := range r.Results() {
+		id := item.OrderID
+		if id == "" && item.Index < len(ids) {
+			id = ids[item.Index]
+		}
+		if item.OK() {
+			res.AcceptedIDs = append(res.AcceptedIDs, id)
+		} else {
+			res.RejectedIDs = append(res.RejectedIDs, id)
+		}
+	}
+	return res
+}
+
+// OrderGroup limits the contracts its orders may fill. Once the limit is hit
+// every order of the group is canceled until the group is reset.
+type OrderGroup struct {
+	ID                  string   `json:"id"`
+	IsAutoCancelEnabled bool     `json:"is_auto_cancel_enabled"`
+	Orders              []string `json:"orders,omitempty"` // order IDs, set by GetOrderGroup
+}
+
+// CreateOrderGroupParams is the body of POST /portfolio/order_groups/create.
+type CreateOrderGroupParams struct {
+	ContractsLimit int `json:"contracts_limit"`
+}
+
+// Validate checks that ContractsLimit is positive.
+func (p *CreateOrderGroupParams) Validate() error {
+	var errs common.ValidationErrors
+	if p.ContractsLimit <= 0 {
+		errs.Add("contracts_limit", "must be positive")
+	}
+	return errs.Err()
+}
+
+// AmendOrder amends a resting order.
+func (c *Client) AmendOrder(ctx context.Context, orderID string, params *AmendOrderParams) (*AmendOrderResponse, error) {
+	var out AmendOrderResponse
+	if err := c.do(ctx, http.MethodPost, "/portfolio/orders/"+url.PathEscape(orderID)+"/amend", nil, params, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DecreaseOrder reduces the remaining count of a resting order.
+func (c *Client) DecreaseOrder(ctx context.Context, orderID string, params *DecreaseOrderParams) (*Order, error) {
+	var out DecreaseOrderResponse
+	if err := c.do(ctx, http.MethodPost, "/portfolio/orders/"+url.PathEscape(orderID)+"/decrease", nil, params, &out); err != nil {
+		return nil, err
+	}
+	return &out.Order, nil
+}
+
+// BatchCreateOrders places up to MaxBatchOrders orders. Orders are accepted or
+// rejected one by one; see BatchCreateOrdersResponse.Results.
+func (c *Client) BatchCreateOrders(ctx context.Context, req *BatchCreateOrdersRequest) (*BatchCreateOrdersResponse, error) {
+	var out BatchCreateOrdersResponse
+	if err := c.do(ctx, http.MethodPost, "/portfolio/orders/batched", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// BatchCancelOrders cancels up to MaxBatchOrders orders. Orders are canceled
+// one by one; see BatchCancelOrdersResponse.Results.
+func (c *Client) BatchCancelOrders(ctx context.Context, req *BatchCancelOrdersRequest) (*BatchCancelOrdersResponse, error) {
+	var out BatchCancelOrdersResponse
+	if err := c.do(ctx, http.MethodDelete, "/portfolio/orders/batched", nil, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateOrderGroup creates an order group and returns its ID.
+func (c *Client) CreateOrderGroup(ctx context.Context, params *CreateOrderGroupParams) (string, error) {
+	var out struct {
+		OrderGroupID string `json:"order_group_id"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/portfolio/order_groups/create", nil, params, &out); err != nil {
+		return "", err
+	}
+	return out.OrderGroupID, nil
+}
+
+// ListOrderGroups lists the user's order groups.
+func (c *Client) ListOrderGroups(ctx context.Context) ([]OrderGroup, error) {
+	var out struct {
+		OrderGroups []OrderGroup `json:"order_groups"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/portfolio/order_groups", nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out.OrderGroups, nil
+}
+
+// GetOrderGroup returns an order group with the IDs of its orders.
+func (c *Client) GetOrderGroup(ctx context.Context, id string) (*OrderGroup, error) {
+	var out OrderGroup
+	if err := c.do(ctx, http.MethodGet, "/portfolio/order_groups/"+url.PathEscape(id), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	out.ID = id
+	return &out, nil
+}
+
+// DeleteOrderGroup deletes an order group and cancels its orders.
+func (c *Client) DeleteOrderGroup(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, "/portfolio/order_groups/"+url.PathEscape(id), nil, nil, nil)
+}
+
+// ResetOrderGroup resets the matched contracts of an order group so that its
+// orders may be placed again.
+func (c *Client) ResetOrderGroup(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodPut, "/portfolio/order_groups/"+url.PathEscape(id)+"/reset", nil, struct{}{}, nil)
+}
@@ -0,0 +1,29 @@
+package kalshi
+
+import "github.com/predictpaul/common"
+
+// ToPage converts the market list to a common.Page.
+// Kalshi returns an empty cursor on the last page.
+func (r *MarketListResponse) ToPage() common.Page[MarketResponse] {
+	return common.NewCursorPage(r.Markets, r.Cursor)
+}
+
+// ListParamsFromPage returns the ListParams requesting the given page.
+func ListParamsFromPage(req common.PageRequest) ListParams {
+	return ListParams{Cursor: req.Cursor, Limit: req.PageSize}
+}
+
+// ToPage converts the market list to a common.Page.
+func (r *MarketsResponse) ToPage() common.Page[Market] {
+	return common.NewCursorPage(r.Markets, r.Cursor)
+}
+
+// ToPage converts the order list to a common.Page.
+func (r *OrdersResponse) ToPage() common.Page[Order] {
+	return common.NewCursorPage(r.Orders, r.Cursor)
+}
+
+// ToPage converts the fill list to a common.Page.
+func (r *FillsResponse) ToPage() common.Page[Fill] {
+	return common.NewCursorPage(r.Fills, r.Cursor)
+}
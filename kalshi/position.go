@@ -0,0 +1,158 @@
+package kalshi
+
+import (
+	"github.com/predictpaul/common"
+	"github.com/predictpaul/common/service"
+	"github.com/shopspring/decimal"
+)
+
+// MarkSource selects the price a position is marked at.
+type MarkSource string
+
+const (
+	MarkBid  MarkSource = "bid"  // best bid of the held side (liquidation value)
+	MarkAsk  MarkSource = "ask"  // best ask of the held side
+	MarkLast MarkSource = "last" // last traded price
+	MarkMid  MarkSource = "mid"  // (bid + ask) / 2
+)
+
+// Side returns the side held: yes for a positive Position, no for a
+// negative one, "" when flat.
+func (p *Position) Side() Side {
+	switch {
+	case p.Position > 0:
+		return SideYes
+	case p.Position < 0:
+		return SideNo
+	default:
+		return ""
+	}
+}
+
+// Count returns the number of contracts held, regardless of side.
+func (p *Position) Count() int {
+	if p.Position < 0 {
+		return -p.Position
+	}
+	return p.Position
+}
+
+// TotalCostDollars returns TotalCost converted from cents to dollars.
+func (p *Position) TotalCostDollars() decimal.Decimal {
+	return CentsToDollars(p.TotalCost)
+}
+
+// AvgCost returns the average cost per contract in dollars, or zero when flat.
+func (p *Position) AvgCost() decimal.Decimal {
+	return avgPrice(p.TotalCostDollars(), decimal.NewFromInt(int64(p.Count())))
+}
+
+// MarkPrice returns the price in dollars of side in m for src, or false when
+// the market has no such price. NO bid/ask fall back to 100 - YES ask/bid when
+// the market does not report them, and the NO last price is 100 - LastPrice.
+// A market with a YES/NO result is marked at 1 for the winning side and 0 for
+// the losing side regardless of src.
+func (m *Market) MarkPrice(side Side, src MarkSource) (decimal.Decimal, bool) {
+	if m.Result == string(SideYes) || m.Result == string(SideNo) {
+		if m.Result == string(side) {
+			return oneDollar, true
+		}
+		return decimal.Zero, true
+	}
+
+	bid, ask, last := m.YesBid, m.YesAsk, m.LastPrice
+	if side == SideNo {
+		bid, ask = m.NoBid, m.NoAsk
+		if bid == 0 && m.YesAsk > 0 {
+			bid = 100 - m.YesAsk
+		}
+		if ask == 0 && m.YesBid > 0 {
+			ask = 100 - m.YesBid
+		}
+		if last > 0 {
+			last = 100 - last
+		}
+	}
+
+	var cents int
+	switch src {
+	case MarkBid:
+		cents = bid
+	case MarkAsk:
+		cents = ask
+	case MarkLast:
+		cents = last
+	case MarkMid:
+		if bid <= 0 || ask <= 0 {
+			return decimal.Zero, false
+		}
+		return CentsToDollars(int64(bid + ask)).Div(decimal.NewFromInt(2)), true
+	}
+	if cents <= 0 {
+		return decimal.Zero, false
+	}
+	return CentsToDollars(int64(cents)), true
+}
+
+// MarkPrice returns the price in dollars of side in the book for src, or false
+// when the book has no such price. MarkLast is not available from a book.
+func (o *Orderbook) MarkPrice(side Side, src MarkSource) (decimal.Decimal, bool) {
+	q := o.Quote(side)
+	switch src {
+	case MarkBid:
+		return q.BestBid, q.HasBid
+	case MarkAsk:
+		return q.BestAsk, q.HasAsk
+	case MarkMid:
+		return q.Mid()
+	default:
+		return decimal.Zero, false
+	}
+}
+
+// PositionItem builds a service.PositionItem for the position marked at price
+// (dollars). Without a mark price the current price, value and PnL are zero.
+// UnrealizedPnLPercent is a percentage of the total cost, rounded to 2 places.
+func (p *Position) PositionItem(price decimal.Decimal, hasPrice bool) service.PositionItem {
+	count := decimal.NewFromInt(int64(p.Count()))
+	cost := p.TotalCostDollars()
+	item := service.PositionItem{
+		TokenID:    p.Ticker,
+		MarketID:   p.Ticker,
+		EventID:    p.EventTicker,
+		Source:     common.MarketTypeKalshi,
+		MarketType: common.MarketTypeKalshi,
+		MarketSide: p.Side().MarketSide(),
+		Shares:     count,
+		Balance:    count,
+		AvgCost:    p.AvgCost(),
+		TotalCost:  cost,
+	}
+	if !hasPrice {
+		return item
+	}
+	item.CurrentPrice = price
+	item.CurrentValue = price.Mul(count)
+	item.UnrealizedPnL = item.CurrentValue.Sub(cost)
+	if cost.IsPositive() {
+		item.UnrealizedPnLPercent = item.UnrealizedPnL.Div(cost).Mul(hundred).Round(2)
+	}
+	return item
+}
+
+// MarkToMarket values the position against m at src and fills in the
+// market status and result.
+func (p *Position) MarkToMarket(m *Market, src MarkSource) service.PositionItem {
+	price, ok := m.MarkPrice(p.Side(), src)
+	item := p.PositionItem(price, ok)
+	item.EventTitle = m.Title
+	item.MarketStatus = m.UnifiedStatus()
+	item.MarketResult = m.Result
+	return item
+}
+
+// MarkToBook values the position against the orderbook at src.
+func (p *Position) MarkToBook(o *Orderbook, src MarkSource) service.PositionItem {
+	price, ok := o.MarkPrice(p.Side(), src)
+	return p.PositionItem(price, ok)
+}
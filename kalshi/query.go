@@ -0,0 +1,93 @@
+package kalshi
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Kalshi list endpoints take their parameters in the query string. Zero
+// values are omitted and tickers are joined with commas.
+
+// Values encodes p as query parameters.
+func (p *ListParams) Values() url.Values {
+	q := url.Values{}
+	setQuery(q, "cursor", p.Cursor)
+	if p.Limit > 0 {
+		q.Set("limit", strconv.Itoa(p.Limit))
+	}
+	return q
+}
+
+// Values encodes p as query parameters.
+func (p *ListMarketsParams) Values() url.Values {
+	q := p.ListParams.Values()
+	setQuery(q, "event_ticker", p.EventTicker)
+	setQuery(q, "status", string(p.Status))
+	setQuery(q, "tickers", strings.Join(p.Tickers, ","))
+	return q
+}
+
+// Values encodes p as query parameters.
+func (p *ListOrdersParams) Values() url.Values {
+	q := p.ListParams.Values()
+	setQuery(q, "ticker", p.Ticker)
+	setQuery(q, "status", string(p.Status))
+	return q
+}
+
+// ListParamsFromValues decodes the pagination query parameters.
+func ListParamsFromValues(q url.Values) (ListParams, error) {
+	p := ListParams{Cursor: q.Get("cursor")}
+	if s := q.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return ListParams{}, fmt.Errorf("kalshi: invalid limit %q", s)
+		}
+		p.Limit = n
+	}
+	return p, nil
+}
+
+// ListMarketsParamsFromValues decodes the query parameters of GET /markets.
+// Tickers are split on commas; repeated tickers parameters are also accepted.
+func ListMarketsParamsFromValues(q url.Values) (ListMarketsParams, error) {
+	lp, err := ListParamsFromValues(q)
+	if err != nil {
+		return ListMarketsParams{}, err
+	}
+	p := ListMarketsParams{
+		ListParams:  lp,
+		EventTicker: q.Get("event_ticker"),
+		Status:      MarketStatus(q.Get("status")),
+	}
+	for _, v := range q["tickers"] {
+		for _, t := range strings.Split(v, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				p.Tickers = append(p.Tickers, t)
+			}
+		}
+	}
+	return p, nil
+}
+
+// ListOrdersParamsFromValues decodes the query parameters of GET /portfolio/orders.
+func ListOrdersParamsFromValues(q url.Values) (ListOrdersParams, error) {
+	lp, err := ListParamsFromValues(q)
+	if err != nil {
+		return ListOrdersParams{}, err
+	}
+	return ListOrdersParams{
+		ListParams: lp,
+		Ticker:     q.Get("ticker"),
+		Status:     OrderStatus(q.Get("status")),
+	}, nil
+}
+
+// setQuery sets key to value unless value is empty.
+func setQuery(q url.Values, key, value string) {
+	if value != "" {
+		q.Set(key, value)
+	}
+}
@@ -0,0 +1,152 @@
+package kalshi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/predictpaul/common"
+	"github.com/predictpaul/common/service"
+)
+
+// Market results.
+const (
+	MarketResultYes    = "yes"
+	MarketResultNo     = "no"
+	MarketResultVoid   = "void"   // market cancelled, costs refunded
+	MarketResultScalar = "scalar" // payout per YES contract given by Settlement.Value
+)
+
+// ClaimActionSettle is the ClaimItem.Action of a settlement payout.
+const ClaimActionSettle = "settle"
+
+// Settlement validation errors.
+var (
+	ErrUnknownMarketResult = errors.New("kalshi: unknown market result")
+	ErrRevenueMismatch     = errors.New("kalshi: settlement revenue mismatch")
+)
+
+// TotalCost returns the cost of both sides in cents.
+func (s *Settlement) TotalCost() int64 {
+	return s.YesCost + s.NoCost
+}
+
+// sidePayout returns the payout in cents per YES and per NO contract.
+func (s *Settlement) sidePayout() (yes, no int64, err error) {
+	switch s.MarketResult {
+	case MarketResultYes:
+		return 100, 0, nil
+	case MarketResultNo:
+		return 0, 100, nil
+	case MarketResultScalar:
+		if s.Value < 0 || s.Value > 100 {
+			return 0, 0, fmt.Errorf("%w: scalar value %d out of range", ErrUnknownMarketResult, s.Value)
+		}
+		return int64(s.Value), int64(100 - s.Value), nil
+	default:
+		return 0, 0, fmt.Errorf("%w: %q", ErrUnknownMarketResult, s.MarketResult)
+	}
+}
+
+// ExpectedRevenue computes the payout in cents from the counts and result:
+//   - yes / no: 100 per winning contract
+//   - scalar: Value per YES contract and 100 - Value per NO contract
+//   - void: YesCost + NoCost refunded
+func (s *Settlement) ExpectedRevenue() (int64, error) {
+	if s.MarketResult == MarketResultVoid {
+		return s.TotalCost(), nil
+	}
+	yes, no, err := s.sidePayout()
+	if err != nil {
+		return 0, err
+	}
+	return int64(s.YesCount)*yes + int64(s.NoCount)*no, nil
+}
+
+// RealizedPnL returns ExpectedRevenue minus the cost of both sides, in cents.
+func (s *Settlement) RealizedPnL() (int64, error) {
+	revenue, err := s.ExpectedRevenue()
+	if err != nil {
+		return 0, err
+	}
+	return revenue - s.TotalCost(), nil
+}
+
+// Validate checks the reported Revenue against ExpectedRevenue.
+// Errors wrap ErrRevenueMismatch or ErrUnknownMarketResult.
+func (s *Settlement) Validate() error {
+	expected, err := s.ExpectedRevenue()
+	if err != nil {
+		return fmt.Errorf("settlement %s: %w", s.Ticker, err)
+	}
+	if expected != s.Revenue {
+		return fmt.Errorf("%w: %s reported %d, expected %d", ErrRevenueMismatch, s.Ticker, s.Revenue, expected)
+	}
+	return nil
+}
+
+// ValidateSettleResponse validates every settlement and checks that
+// resp.SettledCount and resp.TotalRevenue match them. It returns all problems
+// joined with errors.Join, or nil.
+func ValidateSettleResponse(resp *SettleResponse, settlements []Settlement) error {
+	var errs []error
+	var total int64
+	for i := range settlements {
+		s := &settlements[i]
+		if err := s.Validate(); err != nil {
+			errs = append(errs, err)
+		}
+		if expected, err := s.ExpectedRevenue(); err == nil {
+			total += expected
+		}
+	}
+	if resp.SettledCount != len(settlements) {
+		errs = append(errs, fmt.Errorf("%w: settled_count %d, got %d settlements",
+			ErrRevenueMismatch, resp.SettledCount, len(settlements)))
+	}
+	if resp.TotalRevenue != total {
+		errs = append(errs, fmt.Errorf("%w: total_revenue %d, expected %d",
+			ErrRevenueMismatch, resp.TotalRevenue, total))
+	}
+	return errors.Join(errs...)
+}
+
+// ClaimItems returns one service.ClaimItem per side held in the settlement,
+// with the expected revenue of that side as USD and its PnL. Void results
+// refund each side's cost. Amounts are in dollars; IDs are left to the caller.
+func (s *Settlement) ClaimItems() ([]service.ClaimItem, error) {
+	yesPayout, noPayout := int64(0), int64(0)
+	if s.MarketResult != MarketResultVoid {
+		var err error
+		if yesPayout, noPayout, err = s.sidePayout(); err != nil {
+			return nil, fmt.Errorf("settlement %s: %w", s.Ticker, err)
+		}
+	}
+
+	var items []service.ClaimItem
+	add := func(side Side, count int, cost, payout int64) {
+		if count == 0 {
+			return
+		}
+		revenue := int64(count) * payout
+		if s.MarketResult == MarketResultVoid {
+			revenue = cost
+		}
+		items = append(items, service.ClaimItem{
+			Action:       ClaimActionSettle,
+			Status:       "success",
+			MarketType:   common.MarketTypeKalshi,
+			MarketID:     s.Ticker,
+			MarketSide:   side.MarketSide(),
+			TokenID:      s.Ticker,
+			SharesAmount: fmt.Sprint(count),
+			USD:          CentsToDollars(revenue).String(),
+			TotalCost:    CentsToDollars(cost).String(),
+			PnL:          CentsToDollars(revenue - cost).String(),
+			CreatedAt:    s.SettledTime.Format(time.RFC3339),
+		})
+	}
+	add(SideYes, s.YesCount, s.YesCost, yesPayout)
+	add(SideNo, s.NoCount, s.NoCost, noPayout)
+	return items, nil
+}
@@ -0,0 +1,179 @@
+package kalshi
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/predictpaul/common"
+	"github.com/predictpaul/common/service"
+	"github.com/shopspring/decimal"
+)
+
+// ErrSimulationSize is returned when an order has neither a Count nor a BuyMaxCost to size it.
+var ErrSimulationSize = errors.New("kalshi: simulation needs count or buy_max_cost")
+
+// SimulatedFill is the part of a simulated order filled at one price level.
+type SimulatedFill struct {
+	Price int `json:"price"` // cents, on the order's side
+	Count int `json:"count"`
+	Fee   int `json:"fee"` // taker fee in cents
+}
+
+// FillSimulation is the estimated result of executing an order against an Orderbook.
+// Prices and amounts are in cents on the order's side.
+type FillSimulation struct {
+	Ticker     string          `json:"ticker"`
+	Side       Side            `json:"side"`
+	Action     Action          `json:"action"`
+	Type       OrderType       `json:"type"`
+	Requested  int             `json:"requested"` // requested count, 0 when sized by BuyMaxCost only
+	Filled     int             `json:"filled"`
+	Cost       int             `json:"cost"`        // sum of price * count, excluding fees
+	Fees       int             `json:"fees"`        // taker fees, rounded per level
+	TotalCost  int             `json:"total_cost"`  // buy: Cost + Fees paid; sell: Cost - Fees received
+	AvgPrice   decimal.Decimal `json:"avg_price"`   // dollars, Cost / Filled
+	BestPrice  int             `json:"best_price"`  // first level reached
+	WorstPrice int             `json:"worst_price"` // last level reached
+	Slippage   decimal.Decimal `json:"slippage"`    // dollars per contract vs BestPrice, positive = worse
+	Complete   bool            `json:"complete"`    // stopped by the requested count or budget, not by the book or limit
+	Fills      []SimulatedFill `json:"fills"`
+}
+
+// SimulateOrder estimates the fill of p by walking book as a taker.
+//
+// Buys take the asks of p.Side implied by the opposite side's bids; sells hit
+// the bids of p.Side. Limit orders stop at their price. Buys stop at p.Count
+// contracts and, when set, at p.BuyMaxCost cents including fees; sells need
+// p.Count. Taker fees from fees are rounded per level, as each level is a
+// separate trade. The book is read from the cents arrays.
+func SimulateOrder(book *Orderbook, p *CreateOrderParams, fees FeeSchedule) (FillSimulation, error) {
+	sim := FillSimulation{Ticker: p.Ticker, Side: p.Side, Action: p.Action, Type: p.Type, Requested: p.Count}
+	if p.Count <= 0 && (p.Action == ActionSell || p.BuyMaxCost <= 0) {
+		return sim, ErrSimulationSize
+	}
+
+	limit := sidePrice(p.Side, p.YesPrice, p.NoPrice)
+	if p.Type == OrderTypeMarket || limit <= 0 {
+		limit = 0
+	}
+
+	var levels []OrderbookLevel
+	if p.Action == ActionSell {
+		levels = p.bookBids(book)
+	} else if p.Side == SideNo {
+		levels = book.ImpliedNoAsks()
+	} else {
+		levels = book.ImpliedYesAsks()
+	}
+
+	remaining := p.Count
+	budget := p.BuyMaxCost
+	for _, l := range levels {
+		if p.Count > 0 && remaining <= 0 {
+			sim.Complete = true
+			break
+		}
+		if l.Price <= 0 || l.Price >= 100 {
+			continue
+		}
+		if limit > 0 && ((p.Action == ActionSell && l.Price < limit) || (p.Action != ActionSell && l.Price > limit)) {
+			break
+		}
+		n := l.Count
+		if p.Count > 0 && n > remaining {
+			n = remaining
+		}
+		fee := fees.TakerFee(l.Price, n)
+		if p.Action != ActionSell && p.BuyMaxCost > 0 {
+			for n > 0 && n*l.Price+fee > budget {
+				n = min(n-1, budget/l.Price)
+				fee = fees.TakerFee(l.Price, n)
+			}
+			if n < l.Count && (p.Count <= 0 || n < remaining) {
+				// Budget exhausted at this level.
+				sim.Complete = true
+			}
+			if n <= 0 {
+				break
+			}
+			budget -= n*l.Price + fee
+		}
+
+		sim.Fills = append(sim.Fills, SimulatedFill{Price: l.Price, Count: n, Fee: fee})
+		sim.Filled += n
+		sim.Cost += n * l.Price
+		sim.Fees += fee
+		remaining -= n
+		if sim.Complete {
+			break
+		}
+	}
+	if p.Count > 0 && sim.Filled >= p.Count {
+		sim.Complete = true
+	}
+
+	if p.Action == ActionSell {
+		sim.TotalCost = sim.Cost - sim.Fees
+	} else {
+		sim.TotalCost = sim.Cost + sim.Fees
+	}
+	if sim.Filled > 0 {
+		sim.BestPrice = sim.Fills[0].Price
+		sim.WorstPrice = sim.Fills[len(sim.Fills)-1].Price
+		sim.AvgPrice = CentsToDollars(int64(sim.Cost)).Div(decimal.NewFromInt(int64(sim.Filled)))
+		sim.Slippage = sim.AvgPrice.Sub(CentsToDollars(int64(sim.BestPrice)))
+		if p.Action == ActionSell {
+			sim.Slippage = sim.Slippage.Neg()
+		}
+	}
+	return sim, nil
+}
+
+// bookBids returns the bids of p.Side with a positive count, best (highest) price first.
+func (p *CreateOrderParams) bookBids(book *Orderbook) []OrderbookLevel {
+	var raw []OrderbookLevel
+	if p.Side == SideNo {
+		raw = book.GetNoLevels()
+	} else {
+		raw = book.GetYesLevels()
+	}
+	bids := make([]OrderbookLevel, 0, len(raw))
+	for _, l := range raw {
+		if l.Count > 0 {
+			bids = append(bids, l)
+		}
+	}
+	sort.Slice(bids, func(i, j int) bool { return bids[i].Price > bids[j].Price })
+	return bids
+}
+
+// ToServiceOrderItem returns a pre-trade preview of the simulated order as a
+// service.OrderItem. Status is left empty as the order has not been submitted.
+func (s *FillSimulation) ToServiceOrderItem() service.OrderItem {
+	orderType := s.Type.CommonOrderType()
+	if orderType == "" {
+		orderType = common.OrderTypeMarket
+	}
+	return service.OrderItem{
+		MarketType:      common.MarketTypeKalshi,
+		MarketID:        s.Ticker,
+		MarketOutID:     s.Ticker,
+		MarketSide:      s.Side.MarketSide(),
+		TokenID:         s.Ticker,
+		TokenAmount:     CentsToDollars(int64(s.TotalCost)),
+		RequestedAmount: CentsToDollars(int64(s.TotalCost)),
+		OrderDirection:  s.Action.OrderDirection(),
+		OrderType:       orderType,
+		RequestedShares: decimal.NewFromInt(int64(s.Requested)),
+		SharesAmount:    decimal.NewFromInt(int64(s.Filled)),
+		FilledCost:      CentsToDollars(int64(s.Cost)),
+		FilledPrice:     s.AvgPrice,
+		FeesPaid:        CentsToDollars(int64(s.Fees)),
+	}
+}
+
+// ToOrderItem returns a pre-trade preview of the simulated order as a common.OrderItem.
+func (s *FillSimulation) ToOrderItem() common.OrderItem {
+	item := s.ToServiceOrderItem()
+	return item.ToCommon()
+}
@@ -4,7 +4,11 @@
 //	go get github.com/predictpaul/common/kalshi
 package kalshi
 
-import "time"
+import (
+	"time"
+
+	"github.com/predictpaul/common/service"
+)
 
 // Side represents the side of a position (yes/no).
 type Side string
@@ -96,32 +100,60 @@ func (m *Market) IsSettled() bool {
 	return m.Status == MarketStatusFinalized
 }
 
-// IsClosed returns whether the market is closed.
+// IsClosed returns whether the market is closed (trading has ended).
 func (m *Market) IsClosed() bool {
 	return m.Status == MarketStatusClosed ||
 		m.Status == MarketStatusDetermined ||
+		m.Status == MarketStatusDisputed ||
+		m.Status == MarketStatusAmended ||
 		m.Status == MarketStatusFinalized
 }
 
-// GetUnifiedStatus returns a unified status string.
+// IsTradable returns whether the market accepts orders.
+func (m *Market) IsTradable() bool {
+	return m.Status == MarketStatusActive
+}
+
+// IsResolutionFinal returns whether the result can no longer change.
+// A determined or amended result may still be disputed or amended; only a
+// finalized result is safe to pay out.
+func (m *Market) IsResolutionFinal() bool {
+	return m.Status == MarketStatusFinalized
+}
+
+// UnifiedStatus returns the unified market status.
 // Kalshi status mapping:
+//   - initialized -> pending
 //   - active -> open
+//   - inactive -> paused
+//   - closed/determined/amended -> closed
+//   - disputed -> disputed
 //   - finalized -> settled
-//   - closed/determined -> closed
 //   - others -> the original status
-func (m *Market) GetUnifiedStatus() string {
+func (m *Market) UnifiedStatus() service.UnifiedMarketStatus {
 	switch m.Status {
+	case MarketStatusInitialized:
+		return service.MarketStatusPending
 	case MarketStatusActive:
-		return "open"
+		return service.MarketStatusOpen
+	case MarketStatusInactive:
+		return service.MarketStatusPaused
+	case MarketStatusClosed, MarketStatusDetermined, MarketStatusAmended:
+		return service.MarketStatusClosed
+	case MarketStatusDisputed:
+		return service.MarketStatusDisputed
 	case MarketStatusFinalized:
-		return "settled"
-	case MarketStatusClosed, MarketStatusDetermined:
-		return "closed"
+		return service.MarketStatusSettled
 	default:
-		return string(m.Status)
+		return service.UnifiedMarketStatus(m.Status)
 	}
 }
 
+// GetUnifiedStatus returns the unified status as a string. See UnifiedStatus.
+func (m *Market) GetUnifiedStatus() string {
+	return string(m.UnifiedStatus())
+}
+
 // IsYesWinner returns whether the market result is "yes".
 func (m *Market) IsYesWinner() bool {
 	return m.Result == "yes"
@@ -230,6 +262,7 @@ type Settlement struct {
 	YesCost      int64     `json:"yes_cost"`
 	NoCost       int64     `json:"no_cost"`
 	Revenue      int64     `json:"revenue"`
+	Value        int       `json:"value,omitempty"` // scalar markets: payout in cents per YES contract
 	SettledTime  time.Time `json:"settled_time"`
 }
 
@@ -284,6 +317,7 @@ type CreateOrderParams struct {
 	TimeInForce   TimeInForce `json:"time_in_force,omitempty"`
 	ExpirationTS  int64       `json:"expiration_ts,omitempty"`
 	BuyMaxCost    int         `json:"buy_max_cost,omitempty"` // Maximum cost in cents for market orders (auto FoK)
+	OrderGroupID  string      `json:"order_group_id,omitempty"`
 }
 
 // ListParams contains common pagination parameters.
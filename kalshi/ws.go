@@ -0,0 +1,472 @@
+package kalshi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// WebSocket message types.
+const (
+	WSTypeOrderbookSnapshot = "orderbook_snapshot"
+	WSTypeOrderbookDelta    = "orderbook_delta"
+	WSTypeTicker            = "ticker"
+	WSTypeTrade             = "trade"
+	WSTypeFill              = "fill"
+	WSTypeMarketLifecycle   = "market_lifecycle"
+	WSTypeError             = "error"
+)
+
+// WebSocket errors.
+var (
+	ErrUnknownWSType     = errors.New("kalshi: unknown websocket message type")
+	ErrSequenceGap       = errors.New("kalshi: websocket sequence gap")
+	ErrNoSnapshot        = errors.New("kalshi: orderbook delta before snapshot")
+	ErrNegativeLevel     = errors.New("kalshi: orderbook delta leaves a negative count")
+	ErrTickerMismatch    = errors.New("kalshi: orderbook delta for another market")
+	ErrInvalidDeltaSide  = errors.New("kalshi: orderbook delta side must be yes or no")
+	ErrInvalidDeltaPrice = errors.New("kalshi: orderbook delta price must be 1-99 cents")
+)
+
+// WSMessage is the envelope of every message received on the Kalshi
+// WebSocket. Seq increases by one per message of a subscription (SID) on
+// sequenced channels such as the orderbook.
+type WSMessage struct {
+	Type string          `json:"type"`
+	SID  int             `json:"sid"`
+	Seq  int64           `json:"seq,omitempty"`
+	Msg  json.RawMessage `json:"msg"`
+}
+
+// Decode decodes Msg into the type matching Type: *OrderbookSnapshot,
+// *OrderbookDelta, *TickerUpdate, *TradeUpdate, *FillUpdate,
+// *MarketLifecycleUpdate or *WSError.
+func (m *WSMessage) Decode() (any, error) {
+	var v any
+	switch m.Type {
+	case WSTypeOrderbookSnapshot:
+		v = &OrderbookSnapshot{}
+	case WSTypeOrderbookDelta:
+		v = &OrderbookDelta{}
+	case WSTypeTicker:
+		v = &TickerUpdate{}
+	case WSTypeTrade:
+		v = &TradeUpdate{}
+	case WSTypeFill:
+		v = &FillUpdate{}
+	case WSTypeMarketLifecycle:
+		v = &MarketLifecycleUpdate{}
+	case WSTypeError:
+		v = &WSError{}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownWSType, m.Type)
+	}
+	if err := json.Unmarshal(m.Msg, v); err != nil {
+		return nil, fmt.Errorf("kalshi: decode %s: %w", m.Type, err)
+	}
+	return v, nil
+}
+
+// OrderbookSnapshot is the full book sent when subscribing to orderbook_delta.
+type OrderbookSnapshot struct {
+	MarketTicker string  `json:"market_ticker"`
+	Yes          [][]int `json:"yes"`
+	No           [][]int `json:"no"`
+	YesDollars   [][]any `json:"yes_dollars"`
+	NoDollars    [][]any `json:"no_dollars"`
+}
+
+// Orderbook returns the snapshot as an Orderbook.
+func (s *OrderbookSnapshot) Orderbook() Orderbook {
+	return Orderbook{
+		Ticker:     s.MarketTicker,
+		Yes:        s.Yes,
+		No:         s.No,
+		YesDollars: s.YesDollars,
+		NoDollars:  s.NoDollars,
+	}
+}
+
+// OrderbookDelta changes the resting count at one price of one side.
+type OrderbookDelta struct {
+	MarketTicker  string    `json:"market_ticker"`
+	Price         int       `json:"price"`                   // cents
+	PriceDollars  string    `json:"price_dollars,omitempty"` // dollars, may be subpenny
+	Delta         int       `json:"delta"`                   // change in contracts, negative to remove
+	DeltaFP       string    `json:"delta_fp,omitempty"`      // fixed-point change, may be fractional
+	Side          Side      `json:"side"`
+	ClientOrderID string    `json:"client_order_id,omitempty"` // set when caused by the user's own order
+	TS            time.Time `json:"ts"`
+}
+
+// PriceCents returns Price, or PriceDollars rounded to cents when Price is unset.
+func (d *OrderbookDelta) PriceCents() int {
+	if d.Price == 0 && d.PriceDollars != "" {
+		if p, err := decimal.NewFromString(d.PriceDollars); err == nil {
+			return int(DollarsToCents(p))
+		}
+	}
+	return d.Price
+}
+
+// PriceDecimal returns the price in dollars, preferring PriceDollars.
+func (d *OrderbookDelta) PriceDecimal() decimal.Decimal {
+	return dollarsOr(d.PriceDollars, d.Price)
+}
+
+// DeltaDecimal returns the change in contracts, preferring DeltaFP.
+func (d *OrderbookDelta) DeltaDecimal() decimal.Decimal {
+	return fpOr(d.DeltaFP, d.Delta)
+}
+
+// TickerUpdate is a market's top of book and activity on the ticker channel.
+type TickerUpdate struct {
+	MarketTicker       string `json:"market_ticker"`
+	Price              int    `json:"price"` // last traded YES price in cents
+	YesBid             int    `json:"yes_bid"`
+	YesAsk             int    `json:"yes_ask"`
+	PriceDollars       string `json:"price_dollars,omitempty"`
+	YesBidDollars      string `json:"yes_bid_dollars,omitempty"`
+	YesAskDollars      string `json:"yes_ask_dollars,omitempty"`
+	Volume             int64  `json:"volume"`
+	OpenInterest       int64  `json:"open_interest"`
+	DollarVolume       int64  `json:"dollar_volume"`
+	DollarOpenInterest int64  `json:"dollar_open_interest"`
+	TS                 int64  `json:"ts"` // unix seconds
+}
+
+// ApplyTo copies the prices and activity of the update into m.
+func (u *TickerUpdate) ApplyTo(m *Market) {
+	m.LastPrice = u.Price
+	m.YesBid = u.YesBid
+	m.YesAsk = u.YesAsk
+	if u.YesAsk > 0 {
+		m.NoBid = 100 - u.YesAsk
+	}
+	if u.YesBid > 0 {
+		m.NoAsk = 100 - u.YesBid
+	}
+	m.Volume = u.Volume
+	m.OpenInterest = u.OpenInterest
+}
+
+// TradeUpdate is a public trade on the trade channel.
+type TradeUpdate struct {
+	TradeID      string `json:"trade_id"`
+	MarketTicker string `json:"market_ticker"`
+	YesPrice     int    `json:"yes_price"`
+	NoPrice      int    `json:"no_price"`
+	Count        int    `json:"count"`
+	TakerSide    Side   `json:"taker_side"`
+	TS           int64  `json:"ts"` // unix seconds
+}
+
+// FillUpdate is a fill of one of the user's orders on the fill channel.
+type FillUpdate struct {
+	TradeID         string `json:"trade_id"`
+	OrderID         string `json:"order_id"`
+	ClientOrderID   string `json:"client_order_id,omitempty"`
+	MarketTicker    string `json:"market_ticker"`
+	IsTaker         bool   `json:"is_taker"`
+	Side            Side   `json:"side"`
+	Action          Action `json:"action"`
+	YesPrice        int    `json:"yes_price"`
+	YesPriceDollars string `json:"yes_price_dollars,omitempty"`
+	Count           int    `json:"count"`
+	PostPosition    int    `json:"post_position"` // position after the fill, negative = no
+	TS              int64  `json:"ts"`            // unix seconds
+}
+
+// Fill returns the update as a Fill.
+func (u *FillUpdate) Fill() Fill {
+	return Fill{
+		TradeID:     u.TradeID,
+		Ticker:      u.MarketTicker,
+		Side:        u.Side,
+		Action:      u.Action,
+		Count:       u.Count,
+		YesPrice:    u.YesPrice,
+		NoPrice:     100 - u.YesPrice,
+		IsTaker:     u.IsTaker,
+		OrderID:     u.OrderID,
+		CreatedTime: time.Unix(u.TS, 0).UTC(),
+	}
+}
+
+// Market lifecycle event types.
+const (
+	LifecycleCreated          = "created"
+	LifecycleActivated        = "activated"
+	LifecycleDeactivated      = "deactivated"
+	LifecycleCloseDateUpdated = "close_date_updated"
+	LifecycleDetermined       = "determined"
+	LifecycleSettled          = "settled"
+)
+
+// MarketLifecycleUpdate is a market state change on the market_lifecycle channel.
+type MarketLifecycleUpdate struct {
+	EventType       string `json:"event_type"`
+	MarketTicker    string `json:"market_ticker"`
+	OpenTS          int64  `json:"open_ts,omitempty"`
+	CloseTS         int64  `json:"close_ts,omitempty"`
+	Result          string `json:"result,omitempty"`
+	DeterminationTS int64  `json:"determination_ts,omitempty"`
+	SettledTS       int64  `json:"settled_ts,omitempty"`
+	IsDeactivated   bool   `json:"is_deactivated,omitempty"`
+}
+
+// MarketStatus returns the status the market moves to, or "" when the event
+// does not change it (close_date_updated).
+func (u *MarketLifecycleUpdate) MarketStatus() MarketStatus {
+	switch u.EventType {
+	case LifecycleCreated:
+		return MarketStatusInitialized
+	case LifecycleActivated:
+		return MarketStatusActive
+	case LifecycleDeactivated:
+		return MarketStatusInactive
+	case LifecycleDetermined:
+		return MarketStatusDetermined
+	case LifecycleSettled:
+		return MarketStatusFinalized
+	default:
+		return ""
+	}
+}
+
+// ApplyTo updates the status, result and times of m. The status change is
+// checked with ValidateMarketTransition; m is left unchanged on error.
+func (u *MarketLifecycleUpdate) ApplyTo(m *Market) error {
+	if next := u.MarketStatus(); next != "" {
+		if m.Status != "" {
+			if err := ValidateMarketTransition(m.Status, next); err != nil {
+				return err
+			}
+		}
+		m.Status = next
+	}
+	if u.OpenTS > 0 {
+		m.OpenTime = time.Unix(u.OpenTS, 0).UTC()
+	}
+	if u.CloseTS > 0 {
+		m.CloseTime = time.Unix(u.CloseTS, 0).UTC()
+	}
+	if u.Result != "" {
+		m.Result = u.Result
+	}
+	return nil
+}
+
+// WSError is the msg of an error message.
+type WSError struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// Error implements the error interface.
+func (e *WSError) Error() string {
+	return fmt.Sprintf("kalshi: websocket error %d: %s", e.Code, e.Msg)
+}
+
+// ApplyDelta applies d to the book, keeping the cents arrays and, when the
+// book carries them, the dollars arrays in sync. Levels are kept in ascending
+// price order and removed when their count reaches zero. Prices outside 1-99
+// cents are rejected. The book is left unchanged on error.
+func (o *Orderbook) ApplyDelta(d *OrderbookDelta) error {
+	if o.Ticker != "" && d.MarketTicker != "" && d.MarketTicker != o.Ticker {
+		return fmt.Errorf("%w: %s, book is %s", ErrTickerMismatch, d.MarketTicker, o.Ticker)
+	}
+	var cents *[][]int
+	var dollars *[][]any
+	switch d.Side {
+	case SideYes:
+		cents, dollars = &o.Yes, &o.YesDollars
+	case SideNo:
+		cents, dollars = &o.No, &o.NoDollars
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidDeltaSide, d.Side)
+	}
+
+	price := d.PriceCents()
+	if price < 1 || price > 99 {
+		return fmt.Errorf("%w: %s %s %d¢", ErrInvalidDeltaPrice, d.MarketTicker, d.Side, price)
+	}
+	ci := sort.Search(len(*cents), func(i int) bool { return len((*cents)[i]) > 0 && (*cents)[i][0] >= price })
+	centsCount := d.Delta
+	if ci < len(*cents) && len((*cents)[ci]) >= 2 && (*cents)[ci][0] == price {
+		centsCount += (*cents)[ci][1]
+	}
+	if centsCount < 0 {
+		return fmt.Errorf("%w: %s %s %d¢ -> %d", ErrNegativeLevel, d.MarketTicker, d.Side, price, centsCount)
+	}
+
+	syncDollars := o.YesDollars != nil || o.NoDollars != nil
+	dollarPrice := d.PriceDecimal()
+	di, dollarsCount := 0, d.DeltaDecimal()
+	if syncDollars {
+		for di < len(*dollars) {
+			p, err := dollarLevelPrice((*dollars)[di])
+			if err == nil && p.GreaterThanOrEqual(dollarPrice) {
+				break
+			}
+			di++
+		}
+		if di < len(*dollars) {
+			if p, err := dollarLevelPrice((*dollars)[di]); err == nil && p.Equal(dollarPrice) {
+				if c, err := anyDecimal((*dollars)[di][1]); err == nil {
+					dollarsCount = dollarsCount.Add(c)
+				}
+			}
+		}
+		if dollarsCount.IsNegative() {
+			return fmt.Errorf("%w: %s %s $%s -> %s", ErrNegativeLevel, d.MarketTicker, d.Side, dollarPrice, dollarsCount)
+		}
+	}
+
+	*cents = updateCentsLevel(*cents, ci, price, centsCount)
+	if syncDollars {
+		*dollars = updateDollarLevel(*dollars, di, dollarPrice, dollarsCount)
+	}
+	return nil
+}
+
+// dollarLevelPrice parses the price of a dollars array entry.
+func dollarLevelPrice(pair []any) (decimal.Decimal, error) {
+	if len(pair) < 2 {
+		return decimal.Zero, fmt.Errorf("kalshi: malformed orderbook level %v", pair)
+	}
+	return anyDecimal(pair[0])
+}
+
+func updateCentsLevel(levels [][]int, i, price, count int) [][]int {
+	exists := i < len(levels) && len(levels[i]) >= 2 && levels[i][0] == price
+	switch {
+	case exists && count == 0:
+		return append(levels[:i], levels[i+1:]...)
+	case exists:
+		levels[i][1] = count
+		return levels
+	case count == 0:
+		return levels
+	default:
+		return append(levels[:i], append([][]int{{price, count}}, levels[i:]...)...)
+	}
+}
+
+func updateDollarLevel(levels [][]any, i int, price, count decimal.Decimal) [][]any {
+	exists := false
+	if i < len(levels) {
+		if p, err := dollarLevelPrice(levels[i]); err == nil && p.Equal(price) {
+			exists = true
+		}
+	}
+	entry := []any{price.StringFixed(4), json.Number(count.String())}
+	switch {
+	case exists && count.IsZero():
+		return append(levels[:i], levels[i+1:]...)
+	case exists:
+		levels[i] = entry
+		return levels
+	case count.IsZero():
+		return levels
+	default:
+		return append(levels[:i], append([][]any{entry}, levels[i:]...)...)
+	}
+}
+
+// SequenceGapError reports a missed message on a sequenced subscription.
+type SequenceGapError struct {
+	SID      int
+	Expected int64
+	Got      int64
+}
+
+// Error implements the error interface.
+func (e *SequenceGapError) Error() string {
+	return fmt.Sprintf("%v: sid %d expected seq %d, got %d", ErrSequenceGap, e.SID, e.Expected, e.Got)
+}
+
+// Unwrap returns ErrSequenceGap.
+func (e *SequenceGapError) Unwrap() error {
+	return ErrSequenceGap
+}
+
+// OrderbookFeed maintains orderbooks from orderbook_snapshot and
+// orderbook_delta messages. Sequence numbers are tracked per subscription;
+// on a gap every book of the subscription is dropped and the caller should
+// resubscribe to receive fresh snapshots.
+type OrderbookFeed struct {
+	books map[string]*Orderbook
+	sids  map[string]int
+	seqs  map[int]int64
+}
+
+// NewOrderbookFeed returns an empty feed.
+func NewOrderbookFeed() *OrderbookFeed {
+	return &OrderbookFeed{
+		books: make(map[string]*Orderbook),
+		sids:  make(map[string]int),
+		seqs:  make(map[int]int64),
+	}
+}
+
+// Book returns the current book of ticker, or false when there is none.
+// The book is owned by the feed and changes with later messages.
+func (f *OrderbookFeed) Book(ticker string) (*Orderbook, bool) {
+	b, ok := f.books[ticker]
+	return b, ok
+}
+
+// Handle applies an orderbook message and returns the ticker it changed.
+// Other message types are ignored. Errors wrap ErrSequenceGap (as a
+// *SequenceGapError), ErrNoSnapshot or an ApplyDelta error; a gap or a
+// failed delta drops the books of the subscription. A delta without a
+// snapshot still advances the sequence, so it is not followed by a gap.
+func (f *OrderbookFeed) Handle(msg *WSMessage) (string, error) {
+	if msg.Type != WSTypeOrderbookSnapshot && msg.Type != WSTypeOrderbookDelta {
+		return "", nil
+	}
+	if last, ok := f.seqs[msg.SID]; ok && msg.Seq != last+1 {
+		f.reset(msg.SID)
+		return "", &SequenceGapError{SID: msg.SID, Expected: last + 1, Got: msg.Seq}
+	}
+	v, err := msg.Decode()
+	if err != nil {
+		return "", err
+	}
+	switch m := v.(type) {
+	case *OrderbookSnapshot:
+		book := m.Orderbook()
+		f.books[m.MarketTicker] = &book
+		f.sids[m.MarketTicker] = msg.SID
+		f.seqs[msg.SID] = msg.Seq
+		return m.MarketTicker, nil
+	case *OrderbookDelta:
+		f.seqs[msg.SID] = msg.Seq
+		book, ok := f.books[m.MarketTicker]
+		if !ok {
+			return m.MarketTicker, fmt.Errorf("%w: %s", ErrNoSnapshot, m.MarketTicker)
+		}
+		if err := book.ApplyDelta(m); err != nil {
+			f.reset(msg.SID)
+			return m.MarketTicker, err
+		}
+		return m.MarketTicker, nil
+	}
+	return "", nil
+}
+
+// reset drops the sequence and books of sid.
+func (f *OrderbookFeed) reset(sid int) {
+	delete(f.seqs, sid)
+	for ticker, s := range f.sids {
+		if s == sid {
+			delete(f.sids, ticker)
+			delete(f.books, ticker)
+		}
+	}
+}
@@ -30,7 +30,9 @@ const (
 	OrderTypeStop   = "STOP"
 )
 
-// OrderStatus represents the status of an order.
+// OrderStatus values. The constants are untyped so they remain assignable to
+// the string Status fields of the request/response types; see OrderStatus for
+// the typed form and the allowed transitions.
 // Terminal states: FILLED, CANCELLED, FAILED.
 const (
 	OrderStatusSubmitting    = "SUBMITTING"     // 订单已创建，资金已锁定，正在提交到平台
@@ -142,10 +144,12 @@ type OrderItem struct {
 	UserWallet      string     `json:"user_wallet"`
 	OrderTime       *time.Time `json:"order_time"`
 	MarketType      string     `json:"market_type"`
+	MarketAccountID string     `json:"market_account_id,omitempty"`
 	MarketID        string     `json:"market_id"`
 	MarketOutID     string     `json:"market_out_id"`
 	EventID         string     `json:"event_id"`
 	MarketSide      string     `json:"market_side"`
+	MarketOrderID   string     `json:"market_order_id,omitempty"`
 	TokenID         string     `json:"token_id"`
 	TokenAmount     string     `json:"token_amount"`
 	RequestedAmount string     `json:"requested_amount"`
@@ -188,11 +192,14 @@ type EventOrderItem struct {
 	MarketSide      string     `json:"market_side"`
 	TokenID         string     `json:"token_id"`
 	TokenAmount     string     `json:"token_amount"`
+	RequestedAmount string     `json:"requested_amount,omitempty"`
 	OrderDirection  string     `json:"order_direction"`
 	OrderType       string     `json:"order_type"`
 	LimitPrice      string     `json:"limit_price"`
 	RequestedShares string     `json:"requested_shares"`
 	SharesAmount    string     `json:"shares_amount"`
+	StopPrice       string     `json:"stop_price,omitempty"`
+	TakeProfitPrice string     `json:"take_profit_price,omitempty"`
 	FilledCost      string     `json:"filled_cost"`
 	FilledPrice     string     `json:"filled_price"`
 	FeesPaid        string     `json:"fees_paid"`
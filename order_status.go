@@ -0,0 +1,156 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownOrderStatus is returned when a value is not one of the OrderStatus constants.
+var ErrUnknownOrderStatus = errors.New("unknown order status")
+
+// ErrInvalidTransition is returned by Transition for a move the order lifecycle does not allow.
+var ErrInvalidTransition = errors.New("invalid order status transition")
+
+// OrderStatus is the typed form of the OrderStatus* constants.
+//
+// Lifecycle:
+//
+//	SUBMITTING -> PENDING -> PARTIAL_FILLED -> FILLED
+//	SUBMITTING -> FAILED
+//	SUBMITTING / PENDING / PARTIAL_FILLED -> CANCELLING -> CANCELLED
+//
+// Fills may skip intermediate states (e.g. a market order going straight from
+// SUBMITTING to FILLED), the venue may cancel a live order directly (expiry,
+// IOC remainder), and a CANCELLING order may still fill or fall back to a live
+// state if the venue rejects the cancel.
+type OrderStatus string
+
+// orderStatusTransitions lists, for every non-terminal status, the statuses it may move to.
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusSubmitting: {
+		OrderStatusPending,
+		OrderStatusPartialFilled,
+		OrderStatusFilled,
+		OrderStatusCancelling,
+		OrderStatusCancelled,
+		OrderStatusFailed,
+	},
+	OrderStatusPending: {
+		OrderStatusPartialFilled,
+		OrderStatusFilled,
+		OrderStatusCancelling,
+		OrderStatusCancelled,
+	},
+	OrderStatusPartialFilled: {
+		OrderStatusFilled,
+		OrderStatusCancelling,
+		OrderStatusCancelled,
+	},
+	OrderStatusCancelling: {
+		OrderStatusPending,
+		OrderStatusPartialFilled,
+		OrderStatusFilled,
+		OrderStatusCancelled,
+	},
+}
+
+// ParseOrderStatus converts s to an OrderStatus, rejecting unknown values.
+func ParseOrderStatus(s string) (OrderStatus, error) {
+	status := OrderStatus(s)
+	if !status.IsValid() {
+		return "", fmt.Errorf("%w: %q", ErrUnknownOrderStatus, s)
+	}
+	return status, nil
+}
+
+// String returns the status as a plain string.
+func (s OrderStatus) String() string {
+	return string(s)
+}
+
+// IsValid returns true if s is one of the OrderStatus constants.
+func (s OrderStatus) IsValid() bool {
+	switch s {
+	case OrderStatusSubmitting, OrderStatusPending, OrderStatusPartialFilled, OrderStatusCancelling,
+		OrderStatusFilled, OrderStatusCancelled, OrderStatusFailed:
+		return true
+	}
+	return false
+}
+
+// IsTerminal returns true if no further transitions are allowed from s.
+func (s OrderStatus) IsTerminal() bool {
+	return IsTerminalStatus(string(s))
+}
+
+// IsCancellable returns true if s allows user-initiated cancellation.
+func (s OrderStatus) IsCancellable() bool {
+	return IsCancellableStatus(string(s))
+}
+
+// CanTransitionTo returns true if moving from s to next is allowed.
+// Staying in the same status is always allowed, so repeated syncs are no-ops.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	if !s.IsValid() || !next.IsValid() {
+		return false
+	}
+	if s == next {
+		return true
+	}
+	for _, allowed := range orderStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// NextStatuses returns the statuses s may move to, excluding s itself.
+// It returns nil for terminal and unknown statuses.
+func (s OrderStatus) NextStatuses() []OrderStatus {
+	next := orderStatusTransitions[s]
+	if len(next) == 0 {
+		return nil
+	}
+	return append([]OrderStatus(nil), next...)
+}
+
+// Transition validates the move from -> to.
+// Errors wrap ErrUnknownOrderStatus or ErrInvalidTransition.
+func Transition(from, to OrderStatus) error {
+	if !from.IsValid() {
+		return fmt.Errorf("%w: %q", ErrUnknownOrderStatus, string(from))
+	}
+	if !to.IsValid() {
+		return fmt.Errorf("%w: %q", ErrUnknownOrderStatus, string(to))
+	}
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. The zero value marshals as
+// an empty string so that structs with an unset status can be serialized;
+// other unknown statuses are rejected.
+func (s OrderStatus) MarshalText() ([]byte, error) {
+	if s != "" && !s.IsValid() {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownOrderStatus, string(s))
+	}
+	return []byte(s), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. An empty string gives
+// the zero value; other unknown statuses are rejected.
+func (s *OrderStatus) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*s = ""
+		return nil
+	}
+	status, err := ParseOrderStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*s = status
+	return nil
+}
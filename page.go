@@ -0,0 +1,82 @@
+package common
+
+// Page is a single page of results in a pagination-style-independent form.
+//
+// Offset-paginated lists (PageData, OrderListResponse, ...) set Page, PageSize
+// and Total; cursor-paginated lists (Kalshi, Polymarket) set NextCursor, which
+// is empty on the last page. Use the ToPage adapters on each list type to
+// build one.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int64  `json:"total,omitempty"`     // offset style: total item count
+	Page       int    `json:"page,omitempty"`      // offset style: 1-based page number
+	PageSize   int    `json:"page_size,omitempty"` // requested page size
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// PageRequest identifies a page to fetch: by Page number for offset-style
+// lists, by Cursor for cursor-style lists. An empty Cursor with Page 0 or 1
+// requests the first page.
+type PageRequest struct {
+	Page     int    `json:"page,omitempty" form:"page"`
+	PageSize int    `json:"page_size,omitempty" form:"page_size"`
+	Cursor   string `json:"cursor,omitempty" form:"cursor"`
+}
+
+// NewOffsetPage builds a page of an offset-paginated list.
+func NewOffsetPage[T any](items []T, total int64, page, pageSize int) Page[T] {
+	return Page[T]{Items: items, Total: total, Page: page, PageSize: pageSize}
+}
+
+// NewCursorPage builds a page of a cursor-paginated list.
+// nextCursor is empty on the last page.
+func NewCursorPage[T any](items []T, nextCursor string) Page[T] {
+	return Page[T]{Items: items, NextCursor: nextCursor}
+}
+
+// HasNext returns whether another page follows this one.
+func (p Page[T]) HasNext() bool {
+	if p.NextCursor != "" {
+		return true
+	}
+	if p.Page <= 0 || p.PageSize <= 0 {
+		return false
+	}
+	return int64(p.Page)*int64(p.PageSize) < p.Total
+}
+
+// NextRequest returns the request for the following page, or false on the last page.
+func (p Page[T]) NextRequest() (PageRequest, bool) {
+	if !p.HasNext() {
+		return PageRequest{}, false
+	}
+	if p.NextCursor != "" {
+		return PageRequest{Cursor: p.NextCursor, PageSize: p.PageSize}, true
+	}
+	return PageRequest{Page: p.Page + 1, PageSize: p.PageSize}, true
+}
+
+// ToPageData converts an offset-style page to PageData.
+func (p Page[T]) ToPageData() PageData[T] {
+	return PageData[T]{Data: p.Items, Count: int(p.Total), PageNum: p.Page, PageSize: p.PageSize}
+}
+
+// ToPage converts PageData to a Page.
+func (d PageData[T]) ToPage() Page[T] {
+	return NewOffsetPage(d.Data, int64(d.Count), d.PageNum, d.PageSize)
+}
+
+// ToPage converts the order list to a Page.
+func (r *OrderListResponse) ToPage() Page[OrderItem] {
+	return NewOffsetPage(r.Orders, r.Total, r.Page, r.PageSize)
+}
+
+// ToPage converts the event order list to a Page.
+func (r *EventOrdersResponse) ToPage() Page[EventOrderItem] {
+	return NewOffsetPage(r.Orders, r.Total, r.Page, r.PageSize)
+}
+
+// ToPage converts the position list to a Page.
+func (r *PositionResponse) ToPage() Page[PositionItem] {
+	return NewOffsetPage(r.Positions, r.Total, r.Page, r.PageSize)
+}
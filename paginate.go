@@ -0,0 +1,82 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// ErrCursorLoop is returned by Paginate when a page returns a cursor that was already fetched.
+var ErrCursorLoop = errors.New("pagination cursor repeated")
+
+// FetchPageFunc fetches the page identified by req.
+type FetchPageFunc[T any] func(ctx context.Context, req PageRequest) (Page[T], error)
+
+// Paginate yields every item of a paginated list, fetching pages lazily
+// starting at first. Pages are fetched only as the caller keeps iterating:
+//
+//	for order, err := range common.Paginate(ctx, common.PageRequest{PageSize: 100}, fetch) {
+//		if err != nil {
+//			return err
+//		}
+//		// ...
+//	}
+//
+// Iteration ends after the last page (see Page.HasNext: an empty cursor, or
+// page*page_size >= total), after an empty page, or when the caller stops.
+// A fetch error, a cancelled ctx or a repeated cursor is yielded once as
+// (zero, err) and ends the iteration.
+func Paginate[T any](ctx context.Context, first PageRequest, fetch FetchPageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		req := first
+		seen := make(map[string]bool)
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			if req.Cursor != "" {
+				if seen[req.Cursor] {
+					yield(zero, ErrCursorLoop)
+					return
+				}
+				seen[req.Cursor] = true
+			}
+
+			page, err := fetch(ctx, req)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range page.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(page.Items) == 0 {
+				return
+			}
+			next, ok := page.NextRequest()
+			if !ok {
+				return
+			}
+			if next.PageSize == 0 {
+				next.PageSize = req.PageSize
+			}
+			req = next
+		}
+	}
+}
+
+// CollectPages drains seq into a slice, stopping at the first error.
+func CollectPages[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var items []T
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
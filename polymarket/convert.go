@@ -0,0 +1,249 @@
+package polymarket
+
+import (
+	"fmt"
+
+	"github.com/predictpaul/common"
+	"github.com/predictpaul/common/service"
+	"github.com/shopspring/decimal"
+)
+
+// TradeStatus trade status enumeration
+// MATCHED(processing) -> MINED(on-chain) -> CONFIRMED(final state-success) / RETRYING(retrying) / FAILED(final state-failed)
+type TradeStatus string
+
+const (
+	TradeStatusMATCHED   TradeStatus = "MATCHED"
+	TradeStatusMINED     TradeStatus = "MINED"
+	TradeStatusCONFIRMED TradeStatus = "CONFIRMED"
+	TradeStatusRETRYING  TradeStatus = "RETRYING"
+	TradeStatusFAILED    TradeStatus = "FAILED"
+)
+
+// IsFinal returns whether the trade reached a final state (CONFIRMED or FAILED).
+func (s TradeStatus) IsFinal() bool {
+	return s == TradeStatusCONFIRMED || s == TradeStatusFAILED
+}
+
+// CommonStatus maps the trade status onto the common order lifecycle, viewing
+// the trade as the fill of the order that produced it.
+//   - MATCHED / MINED / RETRYING -> PENDING (fill not final yet)
+//   - CONFIRMED -> FILLED
+//   - FAILED -> FAILED
+func (s TradeStatus) CommonStatus() common.OrderStatus {
+	switch s {
+	case TradeStatusMATCHED, TradeStatusMINED, TradeStatusRETRYING:
+		return common.OrderStatusPending
+	case TradeStatusCONFIRMED:
+		return common.OrderStatusFilled
+	case TradeStatusFAILED:
+		return common.OrderStatusFailed
+	default:
+		return ""
+	}
+}
+
+// IsConfirmed returns whether the trade is CONFIRMED.
+func (t *Trade) IsConfirmed() bool {
+	return TradeStatus(t.Status) == TradeStatusCONFIRMED
+}
+
+// CommonStatus maps the order status onto the common order lifecycle.
+// matchedSize and originalSize are in shares and derive partial fills:
+//   - PENDING / DELAY -> SUBMITTING
+//   - LIVE / UNMATCHED -> PENDING, or PARTIAL_FILLED when 0 < matched < original
+//   - MATCHED -> FILLED, or PARTIAL_FILLED when matched < original
+//   - CANCELED -> CANCELLED
+//
+// A zero originalSize means unknown and never yields PARTIAL_FILLED.
+func (s OrderStatus) CommonStatus(matchedSize, originalSize decimal.Decimal) common.OrderStatus {
+	partial := matchedSize.IsPositive() && originalSize.IsPositive() && matchedSize.LessThan(originalSize)
+	switch s {
+	case OrderStatusPENDING, OrderStatusDELAY:
+		return common.OrderStatusSubmitting
+	case OrderStatusLIVE, OrderStatusUNMATCHED:
+		if partial {
+			return common.OrderStatusPartialFilled
+		}
+		return common.OrderStatusPending
+	case OrderStatusMATCHED:
+		if partial {
+			return common.OrderStatusPartialFilled
+		}
+		return common.OrderStatusFilled
+	case OrderStatusCANCELED:
+		return common.OrderStatusCancelled
+	default:
+		return ""
+	}
+}
+
+// OrderFill is the confirmed fill of an order aggregated from its trades.
+type OrderFill struct {
+	Size    decimal.Decimal // shares
+	Cost    decimal.Decimal // USDC, size * price summed per trade
+	Fees    decimal.Decimal // USDC
+	Price   decimal.Decimal // Cost / Size, zero when nothing filled
+	Matched decimal.Decimal // shares in trades that have not FAILED, confirmed or not
+}
+
+// ConfirmedFill aggregates the CONFIRMED trades in which marketOrderID took
+// part, either as the taker order or as one of the maker orders. Size, Cost,
+// Fees and Price count confirmed trades only; Matched also counts trades still
+// MATCHED, MINED or RETRYING. Fees follow Polymarket's formula
+// fee_rate_bps / 10000 * min(price, 1 - price) * size.
+func ConfirmedFill(marketOrderID string, trades []Trade) (OrderFill, error) {
+	var fill OrderFill
+	if marketOrderID == "" {
+		return fill, nil
+	}
+	for i := range trades {
+		t := &trades[i]
+		if TradeStatus(t.Status) == TradeStatusFAILED {
+			continue
+		}
+		confirmed := t.IsConfirmed()
+		if t.TakerOrderID == marketOrderID {
+			if err := fill.add(t.Size, t.Price, t.FeeRateBps, confirmed); err != nil {
+				return OrderFill{}, fmt.Errorf("trade %s: %w", t.ID, err)
+			}
+			continue
+		}
+		for _, mo := range t.MakerOrders {
+			if mo.OrderID != marketOrderID {
+				continue
+			}
+			if err := fill.add(mo.MatchedAmount, mo.Price, mo.FeeRateBps, confirmed); err != nil {
+				return OrderFill{}, fmt.Errorf("trade %s maker order: %w", t.ID, err)
+			}
+		}
+	}
+	if fill.Size.IsPositive() {
+		fill.Price = fill.Cost.Div(fill.Size)
+	}
+	return fill, nil
+}
+
+var (
+	one        = decimal.NewFromInt(1)
+	bpsDivisor = decimal.NewFromInt(10000)
+)
+
+// add records a trade leg; only confirmed legs count towards the fill.
+func (f *OrderFill) add(sizeStr, priceStr, feeRateBpsStr string, confirmed bool) error {
+	size, err := decimal.NewFromString(sizeStr)
+	if err != nil {
+		return fmt.Errorf("invalid size %q: %w", sizeStr, err)
+	}
+	price, err := decimal.NewFromString(priceStr)
+	if err != nil {
+		return fmt.Errorf("invalid price %q: %w", priceStr, err)
+	}
+	feeRate := decimal.Zero
+	if feeRateBpsStr != "" {
+		if feeRate, err = decimal.NewFromString(feeRateBpsStr); err != nil {
+			return fmt.Errorf("invalid fee_rate_bps %q: %w", feeRateBpsStr, err)
+		}
+	}
+	f.Matched = f.Matched.Add(size)
+	if !confirmed {
+		return nil
+	}
+	f.Size = f.Size.Add(size)
+	f.Cost = f.Cost.Add(size.Mul(price))
+	f.Fees = f.Fees.Add(feeRate.Div(bpsDivisor).Mul(decimal.Min(price, one.Sub(price))).Mul(size))
+	return nil
+}
+
+// BuildServiceOrderItem builds a unified service.OrderItem from an order and
+// its related trades. FilledCost, FilledPrice, FeesPaid and SharesAmount come
+// from confirmed trades only (see ConfirmedFill); the order's own filled fields
+// are ignored. The status compares the matched shares (OrderFill.Matched)
+// with the requested share count (Order.SharesAmount), so it does not move
+// back from FILLED to PARTIAL_FILLED as trades confirm one by one. Status may
+// hold either a Polymarket OrderStatus or a common order status; common
+// statuses are kept, except that PENDING is upgraded to PARTIAL_FILLED when
+// some but not all shares are matched.
+func BuildServiceOrderItem(o *Order, trades []Trade) (service.OrderItem, error) {
+	parse := func(field, value string) (decimal.Decimal, error) {
+		if value == "" {
+			return decimal.Zero, nil
+		}
+		d, err := decimal.NewFromString(value)
+		if err != nil {
+			return decimal.Zero, fmt.Errorf("order %s: %s: invalid amount %q: %w", o.ID, field, value, err)
+		}
+		return d, nil
+	}
+	var err error
+	var tokenAmount, limitPrice, shares, stopPrice, takeProfit decimal.Decimal
+	if tokenAmount, err = parse("token_amount", o.TokenAmount); err != nil {
+		return service.OrderItem{}, err
+	}
+	if limitPrice, err = parse("limit_price", o.LimitPrice); err != nil {
+		return service.OrderItem{}, err
+	}
+	if shares, err = parse("shares_amount", o.SharesAmount); err != nil {
+		return service.OrderItem{}, err
+	}
+	if stopPrice, err = parse("stop_price", o.StopPrice); err != nil {
+		return service.OrderItem{}, err
+	}
+	if takeProfit, err = parse("take_profit_price", o.TakeProfitPrice); err != nil {
+		return service.OrderItem{}, err
+	}
+
+	fill, err := ConfirmedFill(o.MarketOrderID, trades)
+	if err != nil {
+		return service.OrderItem{}, fmt.Errorf("order %s: %w", o.ID, err)
+	}
+
+	status := OrderStatus(o.Status).CommonStatus(fill.Matched, shares)
+	if status == "" {
+		status = common.OrderStatus(o.Status)
+		if status == common.OrderStatusPending && fill.Matched.IsPositive() &&
+			shares.IsPositive() && fill.Matched.LessThan(shares) {
+			status = common.OrderStatusPartialFilled
+		}
+	}
+
+	orderTime := o.OrderTime
+	return service.OrderItem{
+		ID:              o.ID,
+		UserWallet:      o.UserWallet,
+		OrderTime:       &orderTime,
+		MarketType:      o.MarketType,
+		MarketAccountID: o.MarketAccountID,
+		MarketID:        o.MarketID,
+		MarketOutID:     o.MarketOutID,
+		EventID:         o.EventID,
+		MarketSide:      o.MarketSide,
+		MarketOrderID:   o.MarketOrderID,
+		TokenID:         o.TokenID,
+		TokenAmount:     tokenAmount,
+		RequestedAmount: tokenAmount,
+		OrderDirection:  o.OrderDirection,
+		OrderType:       o.OrderType,
+		LimitPrice:      limitPrice,
+		RequestedShares: shares,
+		SharesAmount:    fill.Size,
+		StopPrice:       stopPrice,
+		TakeProfitPrice: takeProfit,
+		FilledCost:      fill.Cost,
+		FilledPrice:     fill.Price,
+		FeesPaid:        fill.Fees,
+		Status:          string(status),
+		CreatedAt:       o.CreatedAt,
+		UpdatedAt:       o.UpdatedAt,
+	}, nil
+}
+
+// BuildOrderItem builds a unified common.OrderItem from an order and its
+// related trades. See BuildServiceOrderItem for the field mapping.
+func BuildOrderItem(o *Order, trades []Trade) (common.OrderItem, error) {
+	item, err := BuildServiceOrderItem(o, trades)
+	if err != nil {
+		return common.OrderItem{}, err
+	}
+	return item.ToCommon(), nil
+}
@@ -0,0 +1,22 @@
+package polymarket
+
+import "github.com/predictpaul/common"
+
+// EndCursor is the next_cursor value Polymarket returns on the last page
+// (base64 of "-1").
+const EndCursor = "LTE="
+
+// ToPage converts the trade list to a common.Page.
+// The EndCursor marker is mapped to an empty NextCursor.
+func (r *TradesResponse) ToPage() common.Page[Trade] {
+	next := r.NextCursor
+	if next == EndCursor {
+		next = ""
+	}
+	return common.NewCursorPage(r.Data, next)
+}
+
+// ToPage converts the order list to a common.Page.
+func (r *OrderListResponse) ToPage() common.Page[Order] {
+	return common.NewOffsetPage(r.Orders, int64(r.Total), r.Page, r.PageSize)
+}
@@ -0,0 +1,58 @@
+package polymarket
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Values encodes p as query parameters for GET /data/trades. Empty fields are
+// omitted; Before and After are unix timestamps in seconds.
+func (p *TradeParams) Values() url.Values {
+	q := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	set("id", p.ID)
+	set("maker_address", p.Maker)
+	set("market", p.Market)
+	set("asset_id", p.AssetId)
+	if p.Before > 0 {
+		q.Set("before", strconv.FormatInt(p.Before, 10))
+	}
+	if p.After > 0 {
+		q.Set("after", strconv.FormatInt(p.After, 10))
+	}
+	return q
+}
+
+// TradeParamsFromValues decodes the query parameters of GET /data/trades.
+func TradeParamsFromValues(q url.Values) (TradeParams, error) {
+	p := TradeParams{
+		ID:      q.Get("id"),
+		Maker:   q.Get("maker_address"),
+		Market:  q.Get("market"),
+		AssetId: q.Get("asset_id"),
+	}
+	parseTS := func(key string) (int64, error) {
+		s := q.Get(key)
+		if s == "" {
+			return 0, nil
+		}
+		ts, err := strconv.ParseInt(s, 10, 64)
+		if err != nil || ts < 0 {
+			return 0, fmt.Errorf("polymarket: invalid %s %q: want a unix timestamp", key, s)
+		}
+		return ts, nil
+	}
+	var err error
+	if p.Before, err = parseTS("before"); err != nil {
+		return TradeParams{}, err
+	}
+	if p.After, err = parseTS("after"); err != nil {
+		return TradeParams{}, err
+	}
+	return p, nil
+}
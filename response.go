@@ -15,9 +15,40 @@ type PageData[T any] struct {
 	PageSize int `json:"pageSize"`
 }
 
+// Err returns nil for CodeSuccess, otherwise an *APIError carrying Code and Message.
+func (r *Response[T]) Err() error {
+	if r.Code == CodeSuccess {
+		return nil
+	}
+	return &APIError{Code: r.Code, Message: r.Message}
+}
+
 // Response codes
+// 1xx: general, 2xx: order / trading, 3xx: account, 4xx: venue (Polymarket / Kalshi)
 const (
-	CodeSuccess      = 0
-	CodeFailed       = 101
-	CodeUnauthorized = 102
+	CodeSuccess       = 0
+	CodeFailed        = 101
+	CodeUnauthorized  = 102
+	CodeInvalidParams = 103
+	CodeForbidden     = 104
+	CodeNotFound      = 105
+	CodeRateLimited   = 106
+	CodeInternalError = 107
+
+	CodeMarketNotFound          = 201
+	CodeMarketClosed            = 202
+	CodeInvalidPrice            = 203
+	CodeInvalidAmount           = 204
+	CodeDuplicateIdempotencyKey = 205
+	CodeOrderNotFound           = 206
+	CodeOrderNotCancellable     = 207
+	CodeInvalidStatusTransition = 208
+
+	CodeInsufficientBalance = 301
+	CodeAccountNotFound     = 302
+	CodeWithdrawLimit       = 303
+
+	CodeVenueUnavailable = 401
+	CodeVenueRejected    = 402
+	CodeVenueTimeout     = 403
 )
@@ -0,0 +1,21 @@
+package service
+
+import "github.com/predictpaul/common"
+
+// Expand returns the list items with the top-level fields applied, following
+// the same rules as common.BatchOrderCreateRequest.Expand.
+func (b *BatchOrderCreateRequest) Expand() ([]OrderCreateRequest, error) {
+	return common.ExpandBatch(common.BatchFields{
+		UserWallet:     b.UserWallet,
+		MarketSide:     b.MarketSide,
+		OrderDirection: b.OrderDirection,
+		IdempotencyKey: b.IdempotencyKey,
+	}, b.List, func(o *OrderCreateRequest) common.BatchItemFields {
+		return common.BatchItemFields{
+			UserWallet:     &o.UserWallet,
+			MarketSide:     &o.MarketSide,
+			OrderDirection: &o.OrderDirection,
+			IdempotencyKey: &o.IdempotencyKey,
+		}
+	})
+}
@@ -0,0 +1,568 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/predictpaul/common"
+	"github.com/shopspring/decimal"
+)
+
+// Converters between the string-typed root types (github.com/predictpaul/common)
+// and the decimal-typed service types.
+//
+// *FromCommon functions parse every amount and return an error naming the first
+// unparsable field; an empty string parses as zero. ToCommon methods format
+// decimals with decimal.Decimal.String, so zero comes back as "0", except
+// optional request amounts (json omitempty) where zero is formatted as "".
+// Values survive a round trip; textual forms such as "0.50" come back
+// normalized as "0.5", and an empty required amount comes back as "0".
+
+// amountParser parses string amounts and keeps the first error.
+type amountParser struct {
+	prefix string
+	err    error
+}
+
+func (p *amountParser) parse(field, value string) decimal.Decimal {
+	if value == "" || p.err != nil {
+		return decimal.Zero
+	}
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		p.err = fmt.Errorf("%s%s: invalid amount %q: %w", p.prefix, field, value, err)
+		return decimal.Zero
+	}
+	return d
+}
+
+// formatOptional formats an omitempty request amount, mapping zero to "".
+func formatOptional(d decimal.Decimal) string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.String()
+}
+
+// ---- Order requests ----
+
+// OrderCreateRequestFromCommon converts a root order creation request.
+func OrderCreateRequestFromCommon(r common.OrderCreateRequest) (OrderCreateRequest, error) {
+	p := amountParser{}
+	out := OrderCreateRequest{
+		UserWallet:      r.UserWallet,
+		MarketType:      r.MarketType,
+		TokenID:         r.TokenID,
+		MarketID:        r.MarketID,
+		EventID:         r.EventID,
+		MarketSide:      r.MarketSide,
+		TokenAmount:     p.parse("token_amount", r.TokenAmount),
+		OrderDirection:  r.OrderDirection,
+		OrderType:       r.OrderType,
+		LimitPrice:      p.parse("limit_price", r.LimitPrice),
+		SharesAmount:    p.parse("shares_amount", r.SharesAmount),
+		StopPrice:       p.parse("stop_price", r.StopPrice),
+		TakeProfitPrice: p.parse("take_profit_price", r.TakeProfitPrice),
+		IdempotencyKey:  r.IdempotencyKey,
+		FeesEnabled:     r.FeesEnabled,
+		MarketTags:      r.MarketTags,
+	}
+	return out, p.err
+}
+
+// ToCommon converts the request to its root form.
+func (r *OrderCreateRequest) ToCommon() common.OrderCreateRequest {
+	return common.OrderCreateRequest{
+		UserWallet:      r.UserWallet,
+		MarketType:      r.MarketType,
+		TokenID:         r.TokenID,
+		MarketID:        r.MarketID,
+		EventID:         r.EventID,
+		MarketSide:      r.MarketSide,
+		OrderDirection:  r.OrderDirection,
+		OrderType:       r.OrderType,
+		TokenAmount:     formatOptional(r.TokenAmount),
+		LimitPrice:      formatOptional(r.LimitPrice),
+		SharesAmount:    formatOptional(r.SharesAmount),
+		StopPrice:       formatOptional(r.StopPrice),
+		TakeProfitPrice: formatOptional(r.TakeProfitPrice),
+		IdempotencyKey:  r.IdempotencyKey,
+		FeesEnabled:     r.FeesEnabled,
+		MarketTags:      r.MarketTags,
+	}
+}
+
+// BatchOrderCreateRequestFromCommon converts a root batch order creation request.
+func BatchOrderCreateRequestFromCommon(b common.BatchOrderCreateRequest) (BatchOrderCreateRequest, error) {
+	out := BatchOrderCreateRequest{
+		UserWallet:     b.UserWallet,
+		MarketSide:     b.MarketSide,
+		OrderDirection: b.OrderDirection,
+		IdempotencyKey: b.IdempotencyKey,
+	}
+	if b.List != nil {
+		out.List = make([]OrderCreateRequest, len(b.List))
+	}
+	for i, item := range b.List {
+		o, err := OrderCreateRequestFromCommon(item)
+		if err != nil {
+			return BatchOrderCreateRequest{}, fmt.Errorf("list[%d].%w", i, err)
+		}
+		out.List[i] = o
+	}
+	return out, nil
+}
+
+// ToCommon converts the batch request to its root form.
+func (b *BatchOrderCreateRequest) ToCommon() common.BatchOrderCreateRequest {
+	out := common.BatchOrderCreateRequest{
+		UserWallet:     b.UserWallet,
+		MarketSide:     b.MarketSide,
+		OrderDirection: b.OrderDirection,
+		IdempotencyKey: b.IdempotencyKey,
+	}
+	if b.List != nil {
+		out.List = make([]common.OrderCreateRequest, len(b.List))
+	}
+	for i := range b.List {
+		out.List[i] = b.List[i].ToCommon()
+	}
+	return out
+}
+
+// ---- Order items ----
+
+// OrderItemFromCommon converts a root order item.
+func OrderItemFromCommon(o common.OrderItem) (OrderItem, error) {
+	p := amountParser{}
+	out := OrderItem{
+		ID:              o.ID,
+		UserWallet:      o.UserWallet,
+		OrderTime:       o.OrderTime,
+		MarketType:      o.MarketType,
+		MarketAccountID: o.MarketAccountID,
+		MarketID:        o.MarketID,
+		MarketOutID:     o.MarketOutID,
+		EventID:         o.EventID,
+		MarketSide:      o.MarketSide,
+		MarketOrderID:   o.MarketOrderID,
+		TokenID:         o.TokenID,
+		TokenAmount:     p.parse("token_amount", o.TokenAmount),
+		RequestedAmount: p.parse("requested_amount", o.RequestedAmount),
+		OrderDirection:  o.OrderDirection,
+		OrderType:       o.OrderType,
+		LimitPrice:      p.parse("limit_price", o.LimitPrice),
+		RequestedShares: p.parse("requested_shares", o.RequestedShares),
+		SharesAmount:    p.parse("shares_amount", o.SharesAmount),
+		StopPrice:       p.parse("stop_price", o.StopPrice),
+		TakeProfitPrice: p.parse("take_profit_price", o.TakeProfitPrice),
+		FilledCost:      p.parse("filled_cost", o.FilledCost),
+		FilledPrice:     p.parse("filled_price", o.FilledPrice),
+		FeesPaid:        p.parse("fees_paid", o.FeesPaid),
+		Status:          o.Status,
+		FeesEnabled:     o.FeesEnabled,
+		MarketTags:      o.MarketTags,
+		CreatedAt:       o.CreatedAt,
+		UpdatedAt:       o.UpdatedAt,
+	}
+	return out, p.err
+}
+
+// ToCommon converts the order item to its root form.
+func (o *OrderItem) ToCommon() common.OrderItem {
+	return common.OrderItem{
+		ID:              o.ID,
+		UserWallet:      o.UserWallet,
+		OrderTime:       o.OrderTime,
+		MarketType:      o.MarketType,
+		MarketAccountID: o.MarketAccountID,
+		MarketID:        o.MarketID,
+		MarketOutID:     o.MarketOutID,
+		EventID:         o.EventID,
+		MarketSide:      o.MarketSide,
+		MarketOrderID:   o.MarketOrderID,
+		TokenID:         o.TokenID,
+		TokenAmount:     o.TokenAmount.String(),
+		RequestedAmount: o.RequestedAmount.String(),
+		OrderDirection:  o.OrderDirection,
+		OrderType:       o.OrderType,
+		LimitPrice:      o.LimitPrice.String(),
+		RequestedShares: o.RequestedShares.String(),
+		SharesAmount:    o.SharesAmount.String(),
+		StopPrice:       o.StopPrice.String(),
+		TakeProfitPrice: o.TakeProfitPrice.String(),
+		FilledCost:      o.FilledCost.String(),
+		FilledPrice:     o.FilledPrice.String(),
+		FeesPaid:        o.FeesPaid.String(),
+		Status:          o.Status,
+		FeesEnabled:     o.FeesEnabled,
+		MarketTags:      o.MarketTags,
+		CreatedAt:       o.CreatedAt,
+		UpdatedAt:       o.UpdatedAt,
+	}
+}
+
+// OrderListResponseFromCommon converts a root order list response.
+func OrderListResponseFromCommon(r common.OrderListResponse) (OrderListResponse, error) {
+	out := OrderListResponse{Total: r.Total, Page: r.Page, PageSize: r.PageSize}
+	if r.Orders != nil {
+		out.Orders = make([]OrderItem, len(r.Orders))
+	}
+	for i, item := range r.Orders {
+		o, err := OrderItemFromCommon(item)
+		if err != nil {
+			return OrderListResponse{}, fmt.Errorf("orders[%d].%w", i, err)
+		}
+		out.Orders[i] = o
+	}
+	return out, nil
+}
+
+// ToCommon converts the order list response to its root form.
+func (r *OrderListResponse) ToCommon() common.OrderListResponse {
+	out := common.OrderListResponse{Total: r.Total, Page: r.Page, PageSize: r.PageSize}
+	if r.Orders != nil {
+		out.Orders = make([]common.OrderItem, len(r.Orders))
+	}
+	for i := range r.Orders {
+		out.Orders[i] = r.Orders[i].ToCommon()
+	}
+	return out
+}
+
+// EventOrderItemFromCommon converts a root event order item.
+func EventOrderItemFromCommon(o common.EventOrderItem) (EventOrderItem, error) {
+	p := amountParser{}
+	out := EventOrderItem{
+		ID:              o.ID,
+		UserWallet:      o.UserWallet,
+		OrderTime:       o.OrderTime,
+		MarketType:      o.MarketType,
+		MarketID:        o.MarketID,
+		MarketOutID:     o.MarketOutID,
+		EventID:         o.EventID,
+		MarketSide:      o.MarketSide,
+		TokenID:         o.TokenID,
+		TokenAmount:     p.parse("token_amount", o.TokenAmount),
+		RequestedAmount: p.parse("requested_amount", o.RequestedAmount),
+		OrderDirection:  o.OrderDirection,
+		OrderType:       o.OrderType,
+		LimitPrice:      p.parse("limit_price", o.LimitPrice),
+		RequestedShares: p.parse("requested_shares", o.RequestedShares),
+		SharesAmount:    p.parse("shares_amount", o.SharesAmount),
+		StopPrice:       p.parse("stop_price", o.StopPrice),
+		TakeProfitPrice: p.parse("take_profit_price", o.TakeProfitPrice),
+		FilledCost:      p.parse("filled_cost", o.FilledCost),
+		FilledPrice:     p.parse("filled_price", o.FilledPrice),
+		FeesPaid:        p.parse("fees_paid", o.FeesPaid),
+		Status:          o.Status,
+		FeesEnabled:     o.FeesEnabled,
+		MarketTags:      o.MarketTags,
+		CurrentPrice:    p.parse("current_price", o.CurrentPrice),
+		AvgCost:         p.parse("avg_cost", o.AvgCost),
+		CurrentValue:    p.parse("current_value", o.CurrentValue),
+		PnL:             p.parse("pnl", o.PnL),
+		PnLPercent:      p.parse("pnl_percent", o.PnLPercent),
+		Source:          o.Source,
+		CreatedAt:       o.CreatedAt,
+		UpdatedAt:       o.UpdatedAt,
+	}
+	return out, p.err
+}
+
+// ToCommon converts the event order item to its root form.
+func (o *EventOrderItem) ToCommon() common.EventOrderItem {
+	return common.EventOrderItem{
+		ID:              o.ID,
+		UserWallet:      o.UserWallet,
+		OrderTime:       o.OrderTime,
+		MarketType:      o.MarketType,
+		MarketID:        o.MarketID,
+		MarketOutID:     o.MarketOutID,
+		EventID:         o.EventID,
+		MarketSide:      o.MarketSide,
+		TokenID:         o.TokenID,
+		TokenAmount:     o.TokenAmount.String(),
+		RequestedAmount: formatOptional(o.RequestedAmount),
+		OrderDirection:  o.OrderDirection,
+		OrderType:       o.OrderType,
+		LimitPrice:      o.LimitPrice.String(),
+		RequestedShares: o.RequestedShares.String(),
+		SharesAmount:    o.SharesAmount.String(),
+		StopPrice:       formatOptional(o.StopPrice),
+		TakeProfitPrice: formatOptional(o.TakeProfitPrice),
+		FilledCost:      o.FilledCost.String(),
+		FilledPrice:     o.FilledPrice.String(),
+		FeesPaid:        o.FeesPaid.String(),
+		Status:          o.Status,
+		FeesEnabled:     o.FeesEnabled,
+		MarketTags:      o.MarketTags,
+		CurrentPrice:    o.CurrentPrice.String(),
+		AvgCost:         o.AvgCost.String(),
+		CurrentValue:    o.CurrentValue.String(),
+		PnL:             o.PnL.String(),
+		PnLPercent:      o.PnLPercent.String(),
+		Source:          o.Source,
+		CreatedAt:       o.CreatedAt,
+		UpdatedAt:       o.UpdatedAt,
+	}
+}
+
+// EventOrdersResponseFromCommon converts a root event orders response.
+func EventOrdersResponseFromCommon(r common.EventOrdersResponse) (EventOrdersResponse, error) {
+	out := EventOrdersResponse{Total: r.Total, Page: r.Page, PageSize: r.PageSize}
+	if r.Orders != nil {
+		out.Orders = make([]EventOrderItem, len(r.Orders))
+	}
+	for i, item := range r.Orders {
+		o, err := EventOrderItemFromCommon(item)
+		if err != nil {
+			return EventOrdersResponse{}, fmt.Errorf("orders[%d].%w", i, err)
+		}
+		out.Orders[i] = o
+	}
+	return out, nil
+}
+
+// ToCommon converts the event orders response to its root form.
+func (r *EventOrdersResponse) ToCommon() common.EventOrdersResponse {
+	out := common.EventOrdersResponse{Total: r.Total, Page: r.Page, PageSize: r.PageSize}
+	if r.Orders != nil {
+		out.Orders = make([]common.EventOrderItem, len(r.Orders))
+	}
+	for i := range r.Orders {
+		out.Orders[i] = r.Orders[i].ToCommon()
+	}
+	return out
+}
+
+// ---- Account ----
+
+// DepositRequestFromCommon converts a root deposit request.
+func DepositRequestFromCommon(r common.DepositRequest) (DepositRequest, error) {
+	p := amountParser{}
+	out := DepositRequest{
+		UserWallet:    r.UserWallet,
+		UserTxHash:    r.UserTxHash,
+		ChainName:     r.ChainName,
+		TokenSymbol:   r.TokenSymbol,
+		TokenAmount:   p.parse("token_amount", r.TokenAmount),
+		TokenDecimals: r.TokenDecimals,
+	}
+	return out, p.err
+}
+
+// ToCommon converts the deposit request to its root form.
+func (r *DepositRequest) ToCommon() common.DepositRequest {
+	return common.DepositRequest{
+		UserWallet:    r.UserWallet,
+		UserTxHash:    r.UserTxHash,
+		ChainName:     r.ChainName,
+		TokenSymbol:   r.TokenSymbol,
+		TokenAmount:   r.TokenAmount.String(),
+		TokenDecimals: r.TokenDecimals,
+	}
+}
+
+// WithdrawRequestFromCommon converts a root withdraw request.
+func WithdrawRequestFromCommon(r common.WithdrawRequest) (WithdrawRequest, error) {
+	p := amountParser{}
+	out := WithdrawRequest{
+		UserWallet:  r.UserWallet,
+		ChainName:   r.ChainName,
+		TokenSymbol: r.TokenSymbol,
+		Amount:      p.parse("amount", r.Amount),
+	}
+	return out, p.err
+}
+
+// ToCommon converts the withdraw request to its root form.
+func (r *WithdrawRequest) ToCommon() common.WithdrawRequest {
+	return common.WithdrawRequest{
+		UserWallet:  r.UserWallet,
+		ChainName:   r.ChainName,
+		TokenSymbol: r.TokenSymbol,
+		Amount:      r.Amount.String(),
+	}
+}
+
+// PositionItemFromCommon converts a root position item.
+func PositionItemFromCommon(pos common.PositionItem) (PositionItem, error) {
+	p := amountParser{}
+	out := PositionItem{
+		TokenID:              pos.TokenID,
+		MarketID:             pos.MarketID,
+		EventID:              pos.EventID,
+		EventTitle:           pos.EventTitle,
+		Source:               pos.Source,
+		MarketType:           pos.MarketType,
+		MarketSide:           pos.MarketSide,
+		Shares:               p.parse("shares", pos.Shares),
+		Balance:              p.parse("balance", pos.Balance),
+		AvgCost:              p.parse("avg_cost", pos.AvgCost),
+		TotalCost:            p.parse("total_cost", pos.TotalCost),
+		CurrentPrice:         p.parse("current_price", pos.CurrentPrice),
+		CurrentValue:         p.parse("current_value", pos.CurrentValue),
+		UnrealizedPnL:        p.parse("unrealized_pnl", pos.UnrealizedPnL),
+		UnrealizedPnLPercent: p.parse("unrealized_pnl_percent", pos.UnrealizedPnLPercent),
+		IsSettle:             pos.IsSettle,
+		MarketStatus:         UnifiedMarketStatus(pos.MarketStatus),
+		MarketResult:         pos.MarketResult,
+	}
+	return out, p.err
+}
+
+// ToCommon converts the position item to its root form.
+func (pos *PositionItem) ToCommon() common.PositionItem {
+	return common.PositionItem{
+		TokenID:              pos.TokenID,
+		MarketID:             pos.MarketID,
+		EventID:              pos.EventID,
+		EventTitle:           pos.EventTitle,
+		Source:               pos.Source,
+		MarketType:           pos.MarketType,
+		MarketSide:           pos.MarketSide,
+		Shares:               pos.Shares.String(),
+		Balance:              pos.Balance.String(),
+		AvgCost:              pos.AvgCost.String(),
+		TotalCost:            pos.TotalCost.String(),
+		CurrentPrice:         pos.CurrentPrice.String(),
+		CurrentValue:         pos.CurrentValue.String(),
+		UnrealizedPnL:        pos.UnrealizedPnL.String(),
+		UnrealizedPnLPercent: pos.UnrealizedPnLPercent.String(),
+		IsSettle:             pos.IsSettle,
+		MarketStatus:         string(pos.MarketStatus),
+		MarketResult:         pos.MarketResult,
+	}
+}
+
+// positionsFromCommon converts a root position list, prefixing errors with field.
+func positionsFromCommon(field string, list []common.PositionItem) ([]PositionItem, error) {
+	if list == nil {
+		return nil, nil
+	}
+	out := make([]PositionItem, len(list))
+	for i, item := range list {
+		pos, err := PositionItemFromCommon(item)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d].%w", field, i, err)
+		}
+		out[i] = pos
+	}
+	return out, nil
+}
+
+// positionsToCommon converts a service position list to its root form.
+func positionsToCommon(list []PositionItem) []common.PositionItem {
+	if list == nil {
+		return nil
+	}
+	out := make([]common.PositionItem, len(list))
+	for i := range list {
+		out[i] = list[i].ToCommon()
+	}
+	return out
+}
+
+// PositionResponseFromCommon converts a root position response.
+func PositionResponseFromCommon(r common.PositionResponse) (PositionResponse, error) {
+	positions, err := positionsFromCommon("positions", r.Positions)
+	if err != nil {
+		return PositionResponse{}, err
+	}
+	return PositionResponse{Total: r.Total, Page: r.Page, PageSize: r.PageSize, Positions: positions}, nil
+}
+
+// ToCommon converts the position response to its root form.
+func (r *PositionResponse) ToCommon() common.PositionResponse {
+	return common.PositionResponse{
+		Total:     r.Total,
+		Page:      r.Page,
+		PageSize:  r.PageSize,
+		Positions: positionsToCommon(r.Positions),
+	}
+}
+
+// RewardsResponseFromCommon converts a root rewards response.
+func RewardsResponseFromCommon(r common.RewardsResponse) (RewardsResponse, error) {
+	p := amountParser{}
+	out := RewardsResponse{
+		TotalRewards: p.parse("total_rewards", r.TotalRewards),
+		RewardCount:  r.RewardCount,
+	}
+	return out, p.err
+}
+
+// ToCommon converts the rewards response to its root form.
+func (r *RewardsResponse) ToCommon() common.RewardsResponse {
+	return common.RewardsResponse{
+		TotalRewards: r.TotalRewards.String(),
+		RewardCount:  r.RewardCount,
+	}
+}
+
+// PortfolioResponseFromCommon converts a root portfolio response.
+func PortfolioResponseFromCommon(r common.PortfolioResponse) (PortfolioResponse, error) {
+	p := amountParser{}
+	out := PortfolioResponse{
+		TotalPortfolioValue:  p.parse("total_portfolio_value", r.TotalPortfolioValue),
+		USDBalance:           p.parse("usd_balance", r.USDBalance),
+		FrozenBalance:        p.parse("frozen_balance", r.FrozenBalance),
+		PositionsValue:       p.parse("positions_value", r.PositionsValue),
+		TotalCost:            p.parse("total_cost", r.TotalCost),
+		UnrealizedPnL:        p.parse("unrealized_pnl", r.UnrealizedPnL),
+		UnrealizedPnLPercent: p.parse("unrealized_pnl_percent", r.UnrealizedPnLPercent),
+		MaxPotential:         p.parse("max_potential", r.MaxPotential),
+		PositionCount:        r.PositionCount,
+	}
+	return out, p.err
+}
+
+// ToCommon converts the portfolio response to its root form.
+func (r *PortfolioResponse) ToCommon() common.PortfolioResponse {
+	return common.PortfolioResponse{
+		TotalPortfolioValue:  r.TotalPortfolioValue.String(),
+		USDBalance:           r.USDBalance.String(),
+		FrozenBalance:        r.FrozenBalance.String(),
+		PositionsValue:       r.PositionsValue.String(),
+		TotalCost:            r.TotalCost.String(),
+		UnrealizedPnL:        r.UnrealizedPnL.String(),
+		UnrealizedPnLPercent: r.UnrealizedPnLPercent.String(),
+		MaxPotential:         r.MaxPotential.String(),
+		PositionCount:        r.PositionCount,
+	}
+}
+
+// EventPnLResponseFromCommon converts a root event PnL response.
+func EventPnLResponseFromCommon(r common.EventPnLResponse) (EventPnLResponse, error) {
+	p := amountParser{}
+	out := EventPnLResponse{
+		EventID:       r.EventID,
+		TotalCost:     p.parse("total_cost", r.TotalCost),
+		CurrentValue:  p.parse("current_value", r.CurrentValue),
+		MaxProfit:     p.parse("max_profit", r.MaxProfit),
+		UnrealizedPnL: p.parse("unrealized_pnl", r.UnrealizedPnL),
+		PnLPercent:    p.parse("pnl_percent", r.PnLPercent),
+	}
+	if p.err != nil {
+		return EventPnLResponse{}, p.err
+	}
+	positions, err := positionsFromCommon("positions", r.Positions)
+	if err != nil {
+		return EventPnLResponse{}, err
+	}
+	out.Positions = positions
+	return out, nil
+}
+
+// ToCommon converts the event PnL response to its root form.
+func (r *EventPnLResponse) ToCommon() common.EventPnLResponse {
+	return common.EventPnLResponse{
+		EventID:       r.EventID,
+		TotalCost:     r.TotalCost.String(),
+		CurrentValue:  r.CurrentValue.String(),
+		MaxProfit:     r.MaxProfit.String(),
+		UnrealizedPnL: r.UnrealizedPnL.String(),
+		PnLPercent:    r.PnLPercent.String(),
+		Positions:     positionsToCommon(r.Positions),
+	}
+}
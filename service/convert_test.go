@@ -0,0 +1,389 @@
+package service
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/predictpaul/common"
+	"github.com/shopspring/decimal"
+)
+
+// roundTripCase converts in to the service type and back and expects want.
+type roundTripCase[R any] struct {
+	name string
+	in   R
+	want R
+}
+
+func runRoundTrip[R, S any](t *testing.T, fromCommon func(R) (S, error), toCommon func(*S) R, cases []roundTripCase[R]) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := fromCommon(tc.in)
+			if err != nil {
+				t.Fatalf("FromCommon: %v", err)
+			}
+			if got := toCommon(&s); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("round trip\n got: %+v\nwant: %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+var (
+	testTime      = time.Date(2026, 3, 14, 9, 26, 53, 0, time.UTC)
+	testOrderTime = testTime.Add(-time.Minute)
+)
+
+func orderCreateRequest() common.OrderCreateRequest {
+	return common.OrderCreateRequest{
+		UserWallet:      "0xabc",
+		MarketType:      "POLYMARKET",
+		TokenID:         "tok-yes",
+		MarketID:        "mkt-1",
+		EventID:         "evt-1",
+		MarketSide:      "YES",
+		OrderDirection:  "BUY",
+		OrderType:       "LIMIT",
+		TokenAmount:     "25",
+		LimitPrice:      "0.42",
+		SharesAmount:    "59.5",
+		StopPrice:       "0.3",
+		TakeProfitPrice: "0.8",
+		IdempotencyKey:  "idem-1",
+		FeesEnabled:     true,
+		MarketTags:      []string{"crypto"},
+	}
+}
+
+func orderItem() common.OrderItem {
+	return common.OrderItem{
+		ID:              "ord-1",
+		UserWallet:      "0xabc",
+		OrderTime:       &testOrderTime,
+		MarketType:      "KALSHI",
+		MarketAccountID: "acct-7",
+		MarketID:        "mkt-1",
+		MarketOutID:     "KXBTC-25",
+		EventID:         "evt-1",
+		MarketSide:      "NO",
+		MarketOrderID:   "venue-ord-9",
+		TokenID:         "tok-no",
+		TokenAmount:     "10",
+		RequestedAmount: "12",
+		OrderDirection:  "SELL",
+		OrderType:       "LIMIT",
+		LimitPrice:      "0.61",
+		RequestedShares: "20",
+		SharesAmount:    "15",
+		StopPrice:       "0.4",
+		TakeProfitPrice: "0.9",
+		FilledCost:      "9.15",
+		FilledPrice:     "0.61",
+		FeesPaid:        "0.07",
+		Status:          "PARTIAL_FILLED",
+		FeesEnabled:     true,
+		MarketTags:      []string{"crypto", "Bitcoin"},
+		CreatedAt:       testTime,
+		UpdatedAt:       testTime,
+	}
+}
+
+func eventOrderItem() common.EventOrderItem {
+	return common.EventOrderItem{
+		ID:              "ord-2",
+		UserWallet:      "0xabc",
+		OrderTime:       &testOrderTime,
+		MarketType:      "POLYMARKET",
+		MarketID:        "mkt-2",
+		MarketOutID:     "0xcond",
+		EventID:         "evt-1",
+		MarketSide:      "YES",
+		TokenID:         "tok-yes",
+		TokenAmount:     "30",
+		RequestedAmount: "30",
+		OrderDirection:  "BUY",
+		OrderType:       "MARKET",
+		LimitPrice:      "0.55",
+		RequestedShares: "54.5",
+		SharesAmount:    "54.5",
+		StopPrice:       "0.45",
+		TakeProfitPrice: "0.95",
+		FilledCost:      "29.98",
+		FilledPrice:     "0.55",
+		FeesPaid:        "0.12",
+		Status:          "FILLED",
+		FeesEnabled:     true,
+		MarketTags:      []string{"sport"},
+		CurrentPrice:    "0.6",
+		AvgCost:         "0.55",
+		CurrentValue:    "32.7",
+		PnL:             "2.72",
+		PnLPercent:      "9.07",
+		Source:          "POLYMARKET",
+		CreatedAt:       testTime,
+		UpdatedAt:       testTime,
+	}
+}
+
+func positionItem() common.PositionItem {
+	return common.PositionItem{
+		TokenID:              "tok-yes",
+		MarketID:             "mkt-1",
+		EventID:              "evt-1",
+		EventTitle:           "BTC above 100k",
+		Source:               "KALSHI",
+		MarketType:           "KALSHI",
+		MarketSide:           "YES",
+		Shares:               "40",
+		Balance:              "40",
+		AvgCost:              "0.35",
+		TotalCost:            "14",
+		CurrentPrice:         "0.41",
+		CurrentValue:         "16.4",
+		UnrealizedPnL:        "2.4",
+		UnrealizedPnLPercent: "17.14",
+		IsSettle:             false,
+		MarketStatus:         "open",
+		MarketResult:         "",
+	}
+}
+
+func TestOrderCreateRequestRoundTrip(t *testing.T) {
+	zero, zeroWant := orderCreateRequest(), orderCreateRequest()
+	zero.StopPrice, zero.TakeProfitPrice = "0", "0"
+	zeroWant.StopPrice, zeroWant.TakeProfitPrice = "", "" // optional: zero is unset
+	empty := orderCreateRequest()
+	empty.StopPrice, empty.TakeProfitPrice, empty.SharesAmount = "", "", ""
+	denorm, denormWant := orderCreateRequest(), orderCreateRequest()
+	denorm.LimitPrice, denorm.TokenAmount = "0.420", "25.00"
+
+	runRoundTrip(t, OrderCreateRequestFromCommon, (*OrderCreateRequest).ToCommon, []roundTripCase[common.OrderCreateRequest]{
+		{"full", orderCreateRequest(), orderCreateRequest()},
+		{"zero optional", zero, zeroWant},
+		{"empty optional", empty, empty},
+		{"non-normalized", denorm, denormWant},
+	})
+}
+
+func TestBatchOrderCreateRequestRoundTrip(t *testing.T) {
+	full := common.BatchOrderCreateRequest{
+		UserWallet:     "0xabc",
+		MarketSide:     "YES",
+		OrderDirection: "BUY",
+		IdempotencyKey: "batch-1",
+		List:           []common.OrderCreateRequest{orderCreateRequest(), {TokenID: "tok-2", LimitPrice: "0.1"}},
+	}
+	denorm, denormWant := full, full
+	denorm.List = []common.OrderCreateRequest{{LimitPrice: "0.10"}}
+	denormWant.List = []common.OrderCreateRequest{{LimitPrice: "0.1"}}
+
+	runRoundTrip(t, BatchOrderCreateRequestFromCommon, (*BatchOrderCreateRequest).ToCommon, []roundTripCase[common.BatchOrderCreateRequest]{
+		{"full", full, full},
+		{"nil list", common.BatchOrderCreateRequest{UserWallet: "0xabc"}, common.BatchOrderCreateRequest{UserWallet: "0xabc"}},
+		{"empty list", common.BatchOrderCreateRequest{List: []common.OrderCreateRequest{}}, common.BatchOrderCreateRequest{List: []common.OrderCreateRequest{}}},
+		{"non-normalized", denorm, denormWant},
+	})
+}
+
+func TestOrderItemRoundTrip(t *testing.T) {
+	zero := orderItem()
+	zero.FeesPaid, zero.FilledCost, zero.FilledPrice, zero.SharesAmount = "0", "0", "0", "0"
+	empty, emptyWant := orderItem(), orderItem()
+	empty.FeesPaid, empty.StopPrice = "", ""
+	emptyWant.FeesPaid, emptyWant.StopPrice = "0", "0" // required amounts: empty parses as zero
+	denorm, denormWant := orderItem(), orderItem()
+	denorm.LimitPrice, denorm.FeesPaid = "0.610", "0.0700"
+	noVenue := orderItem()
+	noVenue.MarketAccountID, noVenue.MarketOrderID = "", ""
+
+	runRoundTrip(t, OrderItemFromCommon, (*OrderItem).ToCommon, []roundTripCase[common.OrderItem]{
+		{"full", orderItem(), orderItem()},
+		{"zero", zero, zero},
+		{"empty", empty, emptyWant},
+		{"non-normalized", denorm, denormWant},
+		{"no venue ids", noVenue, noVenue},
+	})
+}
+
+func TestOrderListResponseRoundTrip(t *testing.T) {
+	full := common.OrderListResponse{Total: 41, Page: 3, PageSize: 20, Orders: []common.OrderItem{orderItem()}}
+	denorm, denormWant := full, full
+	denorm.Orders = []common.OrderItem{orderItem()}
+	denorm.Orders[0].FilledCost = "9.150"
+
+	runRoundTrip(t, OrderListResponseFromCommon, (*OrderListResponse).ToCommon, []roundTripCase[common.OrderListResponse]{
+		{"full", full, full},
+		{"nil orders", common.OrderListResponse{Total: 0}, common.OrderListResponse{Total: 0}},
+		{"empty orders", common.OrderListResponse{Orders: []common.OrderItem{}}, common.OrderListResponse{Orders: []common.OrderItem{}}},
+		{"non-normalized", denorm, denormWant},
+	})
+}
+
+func TestEventOrderItemRoundTrip(t *testing.T) {
+	zero, zeroWant := eventOrderItem(), eventOrderItem()
+	zero.PnL, zero.FeesPaid, zero.StopPrice = "0", "0", "0"
+	zeroWant.PnL, zeroWant.FeesPaid, zeroWant.StopPrice = "0", "0", "" // stop_price is optional
+	empty, emptyWant := eventOrderItem(), eventOrderItem()
+	empty.RequestedAmount, empty.TakeProfitPrice, empty.PnLPercent = "", "", ""
+	emptyWant.RequestedAmount, emptyWant.TakeProfitPrice, emptyWant.PnLPercent = "", "", "0"
+	denorm, denormWant := eventOrderItem(), eventOrderItem()
+	denorm.CurrentPrice, denorm.AvgCost = "0.60", "0.5500"
+
+	runRoundTrip(t, EventOrderItemFromCommon, (*EventOrderItem).ToCommon, []roundTripCase[common.EventOrderItem]{
+		{"full", eventOrderItem(), eventOrderItem()},
+		{"zero", zero, zeroWant},
+		{"empty", empty, emptyWant},
+		{"non-normalized", denorm, denormWant},
+	})
+}
+
+func TestEventOrdersResponseRoundTrip(t *testing.T) {
+	full := common.EventOrdersResponse{Total: 1, Page: 1, PageSize: 10, Orders: []common.EventOrderItem{eventOrderItem()}}
+
+	runRoundTrip(t, EventOrdersResponseFromCommon, (*EventOrdersResponse).ToCommon, []roundTripCase[common.EventOrdersResponse]{
+		{"full", full, full},
+		{"nil orders", common.EventOrdersResponse{}, common.EventOrdersResponse{}},
+		{"empty orders", common.EventOrdersResponse{Orders: []common.EventOrderItem{}}, common.EventOrdersResponse{Orders: []common.EventOrderItem{}}},
+	})
+}
+
+func TestDepositRequestRoundTrip(t *testing.T) {
+	full := common.DepositRequest{
+		UserWallet:    "0xabc",
+		UserTxHash:    "0xtx",
+		ChainName:     "polygon",
+		TokenSymbol:   "USDC",
+		TokenAmount:   "150",
+		TokenDecimals: 6,
+	}
+	zero := full
+	zero.TokenAmount, zero.TokenDecimals = "0", 0
+	denorm, denormWant := full, full
+	denorm.TokenAmount = "150.000000"
+
+	runRoundTrip(t, DepositRequestFromCommon, (*DepositRequest).ToCommon, []roundTripCase[common.DepositRequest]{
+		{"full", full, full},
+		{"zero", zero, zero},
+		{"non-normalized", denorm, denormWant},
+	})
+}
+
+func TestWithdrawRequestRoundTrip(t *testing.T) {
+	full := common.WithdrawRequest{UserWallet: "0xabc", ChainName: "polygon", TokenSymbol: "USDC", Amount: "75.25"}
+	zero := full
+	zero.Amount = "0"
+	denorm, denormWant := full, full
+	denorm.Amount = "75.250"
+
+	runRoundTrip(t, WithdrawRequestFromCommon, (*WithdrawRequest).ToCommon, []roundTripCase[common.WithdrawRequest]{
+		{"full", full, full},
+		{"zero", zero, zero},
+		{"non-normalized", denorm, denormWant},
+	})
+}
+
+func TestPositionItemRoundTrip(t *testing.T) {
+	zero := positionItem()
+	zero.UnrealizedPnL, zero.UnrealizedPnLPercent = "0", "0"
+	empty, emptyWant := positionItem(), positionItem()
+	empty.CurrentPrice, empty.CurrentValue = "", ""
+	emptyWant.CurrentPrice, emptyWant.CurrentValue = "0", "0"
+	denorm, denormWant := positionItem(), positionItem()
+	denorm.AvgCost = "0.350"
+
+	runRoundTrip(t, PositionItemFromCommon, (*PositionItem).ToCommon, []roundTripCase[common.PositionItem]{
+		{"full", positionItem(), positionItem()},
+		{"zero", zero, zero},
+		{"empty", empty, emptyWant},
+		{"non-normalized", denorm, denormWant},
+	})
+}
+
+func TestPositionResponseRoundTrip(t *testing.T) {
+	full := common.PositionResponse{Total: 2, Page: 1, PageSize: 50, Positions: []common.PositionItem{positionItem(), positionItem()}}
+
+	runRoundTrip(t, PositionResponseFromCommon, (*PositionResponse).ToCommon, []roundTripCase[common.PositionResponse]{
+		{"full", full, full},
+		{"nil positions", common.PositionResponse{}, common.PositionResponse{}},
+		{"empty positions", common.PositionResponse{Positions: []common.PositionItem{}}, common.PositionResponse{Positions: []common.PositionItem{}}},
+	})
+}
+
+func TestRewardsResponseRoundTrip(t *testing.T) {
+	runRoundTrip(t, RewardsResponseFromCommon, (*RewardsResponse).ToCommon, []roundTripCase[common.RewardsResponse]{
+		{"full", common.RewardsResponse{TotalRewards: "3.5", RewardCount: 2}, common.RewardsResponse{TotalRewards: "3.5", RewardCount: 2}},
+		{"zero", common.RewardsResponse{TotalRewards: "0"}, common.RewardsResponse{TotalRewards: "0"}},
+		{"empty", common.RewardsResponse{}, common.RewardsResponse{TotalRewards: "0"}},
+		{"non-normalized", common.RewardsResponse{TotalRewards: "3.50"}, common.RewardsResponse{TotalRewards: "3.5"}},
+	})
+}
+
+func TestPortfolioResponseRoundTrip(t *testing.T) {
+	full := common.PortfolioResponse{
+		TotalPortfolioValue:  "120.5",
+		USDBalance:           "100",
+		FrozenBalance:        "4.1",
+		PositionsValue:       "20.5",
+		TotalCost:            "18",
+		UnrealizedPnL:        "2.5",
+		UnrealizedPnLPercent: "13.89",
+		MaxPotential:         "40",
+		PositionCount:        3,
+	}
+	zero := full
+	zero.USDBalance, zero.FrozenBalance, zero.UnrealizedPnL = "0", "0", "0"
+	denorm, denormWant := full, full
+	denorm.USDBalance = "100.00"
+
+	runRoundTrip(t, PortfolioResponseFromCommon, (*PortfolioResponse).ToCommon, []roundTripCase[common.PortfolioResponse]{
+		{"full", full, full},
+		{"zero", zero, zero},
+		{"non-normalized", denorm, denormWant},
+	})
+}
+
+func TestEventPnLResponseRoundTrip(t *testing.T) {
+	full := common.EventPnLResponse{
+		EventID:       "evt-1",
+		TotalCost:     "14",
+		CurrentValue:  "16.4",
+		MaxProfit:     "26",
+		UnrealizedPnL: "2.4",
+		PnLPercent:    "17.14",
+		Positions:     []common.PositionItem{positionItem()},
+	}
+	zero := full
+	zero.UnrealizedPnL, zero.PnLPercent, zero.Positions = "0", "0", nil
+
+	runRoundTrip(t, EventPnLResponseFromCommon, (*EventPnLResponse).ToCommon, []roundTripCase[common.EventPnLResponse]{
+		{"full", full, full},
+		{"zero", zero, zero},
+	})
+}
+
+func TestOrderItemToCommonJSON(t *testing.T) {
+	item := OrderItem{ID: "ord-1", FeesPaid: decimal.Zero, FilledCost: decimal.Zero}
+	data, err := json.Marshal(item.ToCommon())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"fees_paid":"0"`, `"filled_cost":"0"`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s missing %s", data, want)
+		}
+	}
+	if strings.Contains(string(data), "market_order_id") {
+		t.Errorf("%s: empty market_order_id should be omitted", data)
+	}
+}
+
+func TestConvertInvalidAmount(t *testing.T) {
+	_, err := OrderListResponseFromCommon(common.OrderListResponse{Orders: []common.OrderItem{{}, {FeesPaid: "abc"}}})
+	if err == nil || !strings.Contains(err.Error(), `orders[1].fees_paid: invalid amount "abc"`) {
+		t.Fatalf("err = %v, want orders[1].fees_paid error", err)
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import "github.com/predictpaul/common"
+
+// ToPage converts the order list to a common.Page.
+func (r *OrderListResponse) ToPage() common.Page[OrderItem] {
+	return common.NewOffsetPage(r.Orders, r.Total, r.Page, r.PageSize)
+}
+
+// ToPage converts the event order list to a common.Page.
+func (r *EventOrdersResponse) ToPage() common.Page[EventOrderItem] {
+	return common.NewOffsetPage(r.Orders, r.Total, r.Page, r.PageSize)
+}
+
+// ToPage converts the open order list to a common.Page.
+func (r *OpenOrderResponse) ToPage() common.Page[OpenOrderItem] {
+	return common.NewOffsetPage(r.Orders, r.Total, r.Page, r.PageSize)
+}
+
+// ToPage converts the order history to a common.Page.
+func (r *OrderHistoryResponse) ToPage() common.Page[OrderHistoryItem] {
+	return common.NewOffsetPage(r.Orders, r.Total, r.Page, r.PageSize)
+}
+
+// ToPage converts the position list to a common.Page.
+func (r *PositionResponse) ToPage() common.Page[PositionItem] {
+	return common.NewOffsetPage(r.Positions, r.Total, r.Page, r.PageSize)
+}
+
+// ToPage converts the transaction list to a common.Page.
+func (r *TransactionResponse) ToPage() common.Page[TransactionItem] {
+	return common.NewOffsetPage(r.List, r.Total, r.Page, r.PageSize)
+}
+
+// ToPage converts the claim list to a common.Page.
+func (r *ClaimResponse) ToPage() common.Page[ClaimItem] {
+	return common.NewOffsetPage(r.List, r.Total, r.Page, r.PageSize)
+}
+
+// ToPage converts the account list to a common.Page.
+func (r *AccountListResponse) ToPage() common.Page[any] {
+	return common.NewOffsetPage(r.Accounts, r.Total, r.Page, r.PageSize)
+}
+
+// ToPage converts the flow list to a common.Page.
+func (r *FlowListResponse) ToPage() common.Page[any] {
+	return common.NewOffsetPage(r.Flows, r.Total, r.Page, r.PageSize)
+}
@@ -132,11 +132,12 @@ type OrderHistoryQuery struct {
 
 // DepositRequest represents a deposit request
 type DepositRequest struct {
-	UserWallet  string          `json:"user_wallet" validate:"required"`
-	UserTxHash  string          `json:"user_tx_hash" validate:"required"`
-	ChainName   string          `json:"chain_name" validate:"required"`
-	TokenSymbol string          `json:"token_symbol" validate:"required"`
-	TokenAmount decimal.Decimal `json:"token_amount" binding:"required"`
+	UserWallet    string          `json:"user_wallet" validate:"required"`
+	UserTxHash    string          `json:"user_tx_hash" validate:"required"`
+	ChainName     string          `json:"chain_name" validate:"required"`
+	TokenSymbol   string          `json:"token_symbol" validate:"required"`
+	TokenAmount   decimal.Decimal `json:"token_amount" binding:"required"`
+	TokenDecimals int             `json:"token_decimals,omitempty"`
 }
 
 // WithdrawRequest represents a withdraw request
@@ -243,11 +244,24 @@ type TokenPosition struct {
 type UnifiedMarketStatus string
 
 const (
-	MarketStatusOpen    UnifiedMarketStatus = "open"
-	MarketStatusClosed  UnifiedMarketStatus = "closed"
-	MarketStatusSettled UnifiedMarketStatus = "settled"
+	MarketStatusPending  UnifiedMarketStatus = "pending"  // created, not yet open for trading
+	MarketStatusOpen     UnifiedMarketStatus = "open"     // trading
+	MarketStatusPaused   UnifiedMarketStatus = "paused"   // trading temporarily halted
+	MarketStatusClosed   UnifiedMarketStatus = "closed"   // trading ended, result not final
+	MarketStatusDisputed UnifiedMarketStatus = "disputed" // result under dispute
+	MarketStatusSettled  UnifiedMarketStatus = "settled"  // result final, positions paid out
 )
 
+// IsTradable returns whether orders can be placed.
+func (s UnifiedMarketStatus) IsTradable() bool {
+	return s == MarketStatusOpen
+}
+
+// IsFinal returns whether the result is final and safe to pay out.
+func (s UnifiedMarketStatus) IsFinal() bool {
+	return s == MarketStatusSettled
+}
+
 // PositionItem represents single position information
 type PositionItem struct {
 	TokenID              string              `json:"token_id"`
@@ -294,6 +308,7 @@ type RewardsResponse struct {
 type PortfolioResponse struct {
 	TotalPortfolioValue  decimal.Decimal `json:"total_portfolio_value"`
 	USDBalance           decimal.Decimal `json:"usd_balance"`
+	FrozenBalance        decimal.Decimal `json:"frozen_balance"`
 	PositionsValue       decimal.Decimal `json:"positions_value"`
 	TotalCost            decimal.Decimal `json:"total_cost"`
 	UnrealizedPnL        decimal.Decimal `json:"unrealized_pnl"`
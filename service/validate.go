@@ -0,0 +1,25 @@
+package service
+
+import "github.com/predictpaul/common"
+
+// Validate checks the request against its OrderType and OrderDirection using
+// the same rules as common.OrderCreateRequest.Validate.
+// It returns common.ValidationErrors listing every invalid field, or nil.
+func (r *OrderCreateRequest) Validate() error {
+	var errs common.ValidationErrors
+	common.ValidateOrderFields(common.OrderFields{
+		UserWallet:      r.UserWallet,
+		MarketType:      r.MarketType,
+		TokenID:         r.TokenID,
+		MarketID:        r.MarketID,
+		MarketSide:      r.MarketSide,
+		OrderDirection:  r.OrderDirection,
+		OrderType:       r.OrderType,
+		TokenAmount:     r.TokenAmount,
+		LimitPrice:      r.LimitPrice,
+		SharesAmount:    r.SharesAmount,
+		StopPrice:       r.StopPrice,
+		TakeProfitPrice: r.TakeProfitPrice,
+	}, &errs)
+	return errs.Err()
+}
@@ -0,0 +1,211 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// FieldError describes a single invalid field. Field is the JSON field name.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors collects every field error found by a Validate method.
+type ValidationErrors []FieldError
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Add appends a field error.
+func (e *ValidationErrors) Add(field, message string) {
+	*e = append(*e, FieldError{Field: field, Message: message})
+}
+
+// Err returns e as an error, or nil if no field errors were collected.
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// IsValidMarketType returns true if s is one of the MarketType constants.
+func IsValidMarketType(s string) bool {
+	return s == MarketTypePolymarket || s == MarketTypeKalshi || s == MarketTypeOpinion
+}
+
+// IsValidMarketSide returns true if s is one of the MarketSide constants.
+func IsValidMarketSide(s string) bool {
+	return s == MarketSideYES || s == MarketSideNO
+}
+
+// IsValidOrderDirection returns true if s is one of the OrderDirection constants.
+func IsValidOrderDirection(s string) bool {
+	return s == OrderDirectionBUY || s == OrderDirectionSELL
+}
+
+// IsValidOrderType returns true if s is one of the OrderType constants.
+func IsValidOrderType(s string) bool {
+	return s == OrderTypeMarket || s == OrderTypeLimit || s == OrderTypeStop
+}
+
+// IsValidPrice returns true if p is a probability price strictly between 0 and 1.
+func IsValidPrice(p decimal.Decimal) bool {
+	return p.IsPositive() && p.LessThan(decimal.NewFromInt(1))
+}
+
+// OrderFields is the type-independent view of an order creation request used
+// by ValidateOrderFields. Amounts and prices are zero when unset.
+type OrderFields struct {
+	UserWallet      string
+	MarketType      string
+	TokenID         string
+	MarketID        string
+	MarketSide      string
+	OrderDirection  string
+	OrderType       string
+	TokenAmount     decimal.Decimal
+	LimitPrice      decimal.Decimal
+	SharesAmount    decimal.Decimal
+	StopPrice       decimal.Decimal
+	TakeProfitPrice decimal.Decimal
+}
+
+// ValidateOrderFields appends to errs every rule f violates:
+//   - user_wallet, token_id and market_id are required
+//   - market_type, market_side, order_direction and order_type must be known values
+//   - LIMIT needs limit_price in (0,1)
+//   - MARKET BUY needs token_amount, MARKET SELL needs shares_amount
+//   - STOP needs stop_price in (0,1)
+//   - amounts must not be negative and any price that is set must be in (0,1)
+func ValidateOrderFields(f OrderFields, errs *ValidationErrors) {
+	if f.UserWallet == "" {
+		errs.Add("user_wallet", "is required")
+	}
+	if f.TokenID == "" {
+		errs.Add("token_id", "is required")
+	}
+	if f.MarketID == "" {
+		errs.Add("market_id", "is required")
+	}
+	if !IsValidMarketType(f.MarketType) {
+		errs.Add("market_type", "must be one of POLYMARKET, KALSHI, OPINION")
+	}
+	if !IsValidMarketSide(f.MarketSide) {
+		errs.Add("market_side", "must be one of YES, NO")
+	}
+	if !IsValidOrderDirection(f.OrderDirection) {
+		errs.Add("order_direction", "must be one of BUY, SELL")
+	}
+
+	if f.TokenAmount.IsNegative() {
+		errs.Add("token_amount", "must not be negative")
+	}
+	if f.SharesAmount.IsNegative() {
+		errs.Add("shares_amount", "must not be negative")
+	}
+	if !f.TakeProfitPrice.IsZero() && !IsValidPrice(f.TakeProfitPrice) {
+		errs.Add("take_profit_price", "must be between 0 and 1")
+	}
+
+	switch f.OrderType {
+	case OrderTypeLimit:
+		if !IsValidPrice(f.LimitPrice) {
+			errs.Add("limit_price", "must be between 0 and 1 for LIMIT orders")
+		}
+	case OrderTypeMarket:
+		switch f.OrderDirection {
+		case OrderDirectionBUY:
+			if !f.TokenAmount.IsPositive() {
+				errs.Add("token_amount", "is required for MARKET BUY orders")
+			}
+		case OrderDirectionSELL:
+			if !f.SharesAmount.IsPositive() {
+				errs.Add("shares_amount", "is required for MARKET SELL orders")
+			}
+		}
+	case OrderTypeStop:
+		if !IsValidPrice(f.StopPrice) {
+			errs.Add("stop_price", "must be between 0 and 1 for STOP orders")
+		}
+	default:
+		errs.Add("order_type", "must be one of MARKET, LIMIT, STOP")
+	}
+
+	if f.OrderType != OrderTypeLimit && !f.LimitPrice.IsZero() && !IsValidPrice(f.LimitPrice) {
+		errs.Add("limit_price", "must be between 0 and 1")
+	}
+	if f.OrderType != OrderTypeStop && !f.StopPrice.IsZero() && !IsValidPrice(f.StopPrice) {
+		errs.Add("stop_price", "must be between 0 and 1")
+	}
+}
+
+// Validate checks the request against its OrderType and OrderDirection.
+// It returns ValidationErrors listing every invalid field, or nil.
+func (r *OrderCreateRequest) Validate() error {
+	var errs ValidationErrors
+	f := OrderFields{
+		UserWallet:     r.UserWallet,
+		MarketType:     r.MarketType,
+		TokenID:        r.TokenID,
+		MarketID:       r.MarketID,
+		MarketSide:     r.MarketSide,
+		OrderDirection: r.OrderDirection,
+		OrderType:      r.OrderType,
+	}
+	f.TokenAmount = parseDecimalField("token_amount", r.TokenAmount, &errs)
+	f.LimitPrice = parseDecimalField("limit_price", r.LimitPrice, &errs)
+	f.SharesAmount = parseDecimalField("shares_amount", r.SharesAmount, &errs)
+	f.StopPrice = parseDecimalField("stop_price", r.StopPrice, &errs)
+	f.TakeProfitPrice = parseDecimalField("take_profit_price", r.TakeProfitPrice, &errs)
+
+	// A field that failed to parse is already reported; drop the follow-up
+	// "is required" errors ValidateOrderFields would add for the zero value.
+	parsed := len(errs)
+	ValidateOrderFields(f, &errs)
+	return dedupeFieldErrors(errs, parsed).Err()
+}
+
+// parseDecimalField parses an optional string amount, recording a field error on failure.
+func parseDecimalField(field, value string, errs *ValidationErrors) decimal.Decimal {
+	if value == "" {
+		return decimal.Zero
+	}
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		errs.Add(field, "must be a decimal number")
+		return decimal.Zero
+	}
+	return d
+}
+
+// dedupeFieldErrors keeps the first n errors and drops later errors for the same fields.
+func dedupeFieldErrors(errs ValidationErrors, n int) ValidationErrors {
+	if n == 0 {
+		return errs
+	}
+	seen := make(map[string]bool, n)
+	for _, fe := range errs[:n] {
+		seen[fe.Field] = true
+	}
+	out := errs[:n]
+	for _, fe := range errs[n:] {
+		if !seen[fe.Field] {
+			out = append(out, fe)
+		}
+	}
+	return out
+}